@@ -0,0 +1,123 @@
+// Package bankfile tạo file chuyển khoản lương hàng loạt theo định dạng của ngân hàng
+// và đọc file kết quả ngân hàng trả về để đối soát.
+package bankfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrFieldOverflow báo một giá trị không vừa độ rộng cột của định dạng file
+var ErrFieldOverflow = errors.New("value does not fit the bank file field")
+
+// Batch là một lô chuyển khoản gửi cho ngân hàng
+type Batch struct {
+	Reference    string    // Mã lô
+	DebitAccount string    // Tài khoản công ty bị trích nợ
+	ValueDate    time.Time // Ngày thực hiện chuyển khoản
+	Records      []Record
+}
+
+// Record là một dòng chuyển khoản cho một nhân viên
+type Record struct {
+	Reference     string // Mã tham chiếu dùng để đối soát, ví dụ "SAL00000012"
+	BankCode      string
+	AccountNumber string
+	AccountHolder string
+	Amount        int64
+	Description   string
+}
+
+// Result là một dòng trong file kết quả ngân hàng trả về
+type Result struct {
+	Reference     string
+	AccountNumber string
+	Amount        int64
+	Success       bool
+	TransactionID string
+	Message       string
+}
+
+// Total trả về tổng số tiền của lô
+func (b Batch) Total() int64 {
+	var total int64
+	for _, r := range b.Records {
+		total += r.Amount
+	}
+	return total
+}
+
+// Format là một định dạng file chuyển khoản của ngân hàng
+type Format interface {
+	Name() string
+	ContentType() string
+	Extension() string
+	Write(w io.Writer, batch Batch) error
+	ParseResults(r io.Reader) ([]Result, error)
+}
+
+var formats = map[string]Format{}
+
+// Register đăng ký một định dạng để có thể tra cứu theo tên
+func Register(format Format) {
+	formats[format.Name()] = format
+}
+
+// Lookup tìm định dạng theo tên
+func Lookup(name string) (Format, error) {
+	format, ok := formats[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown bank file format %q", name)
+	}
+	return format, nil
+}
+
+// Names trả về danh sách tên các định dạng đã đăng ký
+func Names() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// vietnameseFold ánh xạ ký tự tiếng Việt có dấu sang không dấu
+var vietnameseFold = map[rune]string{}
+
+func init() {
+	groups := map[string]string{
+		"a": "àáảãạăằắẳẵặâầấẩẫậ",
+		"e": "èéẻẽẹêềếểễệ",
+		"i": "ìíỉĩị",
+		"o": "òóỏõọôồốổỗộơờớởỡợ",
+		"u": "ùúủũụưừứửữự",
+		"y": "ỳýỷỹỵ",
+		"d": "đ",
+	}
+	for base, chars := range groups {
+		for _, r := range chars {
+			vietnameseFold[r] = base
+			vietnameseFold[[]rune(strings.ToUpper(string(r)))[0]] = strings.ToUpper(base)
+		}
+	}
+}
+
+// RemoveDiacritics bỏ dấu tiếng Việt vì hầu hết ngân hàng chỉ chấp nhận ký tự ASCII
+func RemoveDiacritics(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if folded, ok := vietnameseFold[r]; ok {
+			b.WriteString(folded)
+		} else if r < 128 {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return b.String()
+}
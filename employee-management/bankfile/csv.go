@@ -0,0 +1,104 @@
+package bankfile
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVFormat là định dạng CSV chung, dùng được với cổng ngân hàng hỗ trợ nhập CSV
+type CSVFormat struct{}
+
+func (CSVFormat) Name() string        { return "csv" }
+func (CSVFormat) ContentType() string { return "text/csv" }
+func (CSVFormat) Extension() string   { return "csv" }
+
+// csvHeader là tiêu đề cột của file CSV chuyển khoản
+var csvHeader = []string{"reference", "bank_code", "account_number", "account_holder", "amount", "description"}
+
+func (CSVFormat) Write(w io.Writer, batch Batch) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range batch.Records {
+		row := []string{
+			r.Reference,
+			r.BankCode,
+			r.AccountNumber,
+			RemoveDiacritics(r.AccountHolder),
+			strconv.FormatInt(r.Amount, 10),
+			RemoveDiacritics(r.Description),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ParseResults đọc file kết quả CSV với các cột reference, account_number, amount, status
+// và tùy chọn transaction_id, message. Trạng thái "SUCCESS" hoặc "OK" được coi là thành công.
+func (CSVFormat) ParseResults(r io.Reader) ([]Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("result file is empty")
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"reference", "amount", "status"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("result file is missing column %q", required)
+		}
+	}
+
+	get := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	results := make([]Result, 0, len(rows)-1)
+	for line, row := range rows[1:] {
+		amount, err := strconv.ParseInt(get(row, "amount"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount: %w", line+2, err)
+		}
+		results = append(results, Result{
+			Reference:     get(row, "reference"),
+			AccountNumber: get(row, "account_number"),
+			Amount:        amount,
+			Success:       isSuccessStatus(get(row, "status")),
+			TransactionID: get(row, "transaction_id"),
+			Message:       get(row, "message"),
+		})
+	}
+	return results, nil
+}
+
+// isSuccessStatus nhận biết các mã trạng thái thành công thường gặp
+func isSuccessStatus(status string) bool {
+	switch strings.ToUpper(strings.TrimSpace(status)) {
+	case "SUCCESS", "OK", "00", "S", "THANH CONG":
+		return true
+	}
+	return false
+}
+
+func init() {
+	Register(CSVFormat{})
+}
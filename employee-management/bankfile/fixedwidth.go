@@ -0,0 +1,240 @@
+package bankfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Field là một cột có độ rộng cố định trong file ngân hàng.
+// Nếu Value khác rỗng, cột luôn mang giá trị hằng (ví dụ loại bản ghi "D").
+// Giá trị dài hơn độ rộng cột là lỗi, trừ cột ghi chú tự do có Truncate được cắt bớt.
+type Field struct {
+	Name     string
+	Width    int
+	Numeric  bool
+	Truncate bool
+	Value    string
+}
+
+// FixedWidthFormat là định dạng file có độ rộng cột cố định, mỗi lô gồm
+// một dòng header, các dòng chi tiết và một dòng trailer.
+type FixedWidthFormat struct {
+	FormatName string
+	DateLayout string
+	Header     []Field
+	Detail     []Field
+	Trailer    []Field
+	Result     []Field
+}
+
+func (f FixedWidthFormat) Name() string      { return f.FormatName }
+func (FixedWidthFormat) ContentType() string { return "text/plain" }
+func (FixedWidthFormat) Extension() string   { return "txt" }
+
+func (f FixedWidthFormat) Write(w io.Writer, batch Batch) error {
+	batchValues := map[string]string{
+		"batch_reference": batch.Reference,
+		"debit_account":   batch.DebitAccount,
+		"value_date":      batch.ValueDate.Format(f.DateLayout),
+		"record_count":    strconv.Itoa(len(batch.Records)),
+		"total_amount":    strconv.FormatInt(batch.Total(), 10),
+	}
+
+	if err := f.writeLine(w, f.Header, batchValues); err != nil {
+		return err
+	}
+	for i, r := range batch.Records {
+		values := map[string]string{
+			"sequence":       strconv.Itoa(i + 1),
+			"reference":      r.Reference,
+			"bank_code":      r.BankCode,
+			"account_number": r.AccountNumber,
+			"account_holder": strings.ToUpper(RemoveDiacritics(r.AccountHolder)),
+			"amount":         strconv.FormatInt(r.Amount, 10),
+			"description":    RemoveDiacritics(r.Description),
+			"value_date":     batchValues["value_date"],
+		}
+		if err := f.writeLine(w, f.Detail, values); err != nil {
+			return err
+		}
+	}
+	return f.writeLine(w, f.Trailer, batchValues)
+}
+
+// writeLine ghi một dòng theo danh sách cột, bỏ qua nếu layout không có dòng này
+func (f FixedWidthFormat) writeLine(w io.Writer, fields []Field, values map[string]string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	var line strings.Builder
+	for _, field := range fields {
+		value := field.Value
+		if value == "" {
+			value = values[field.Name]
+		}
+		cell, err := pad(value, field)
+		if err != nil {
+			return err
+		}
+		line.WriteString(cell)
+	}
+	line.WriteString("\r\n")
+	_, err := io.WriteString(w, line.String())
+	return err
+}
+
+// pad căn lề giá trị theo độ rộng cột: số căn phải và thêm số 0, chữ căn trái và thêm khoảng trắng.
+// Giá trị không vừa cột trả về ErrFieldOverflow để không gửi ngân hàng số tài khoản hay tên người nhận bị cắt.
+func pad(value string, field Field) (string, error) {
+	runes := []rune(value)
+	if field.Numeric {
+		if len(runes) > field.Width {
+			return "", fmt.Errorf("%w: value %q overflows numeric field %s (width %d)", ErrFieldOverflow, value, field.Name, field.Width)
+		}
+		return strings.Repeat("0", field.Width-len(runes)) + value, nil
+	}
+	if len(runes) > field.Width {
+		if !field.Truncate {
+			return "", fmt.Errorf("%w: value %q overflows field %s (width %d)", ErrFieldOverflow, value, field.Name, field.Width)
+		}
+		return string(runes[:field.Width]), nil
+	}
+	return value + strings.Repeat(" ", field.Width-len(runes)), nil
+}
+
+// ParseResults đọc file kết quả theo layout Result. Những dòng không khớp
+// cột hằng (ví dụ header/trailer) sẽ được bỏ qua.
+func (f FixedWidthFormat) ParseResults(r io.Reader) ([]Result, error) {
+	var results []Result
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := []rune(strings.TrimRight(scanner.Text(), "\r"))
+		if len(line) == 0 {
+			continue
+		}
+
+		values := map[string]string{}
+		offset := 0
+		matched := true
+		for _, field := range f.Result {
+			end := offset + field.Width
+			if end > len(line) {
+				end = len(line)
+			}
+			cell := ""
+			if offset < end {
+				cell = strings.TrimSpace(string(line[offset:end]))
+			}
+			if field.Value != "" && cell != field.Value {
+				matched = false
+				break
+			}
+			values[field.Name] = cell
+			offset += field.Width
+		}
+		if !matched {
+			continue
+		}
+
+		digits := strings.TrimLeft(values["amount"], "0")
+		if digits == "" {
+			digits = "0"
+		}
+		amount, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount %q", lineNo, values["amount"])
+		}
+		results = append(results, Result{
+			Reference:     values["reference"],
+			AccountNumber: values["account_number"],
+			Amount:        amount,
+			Success:       isSuccessStatus(values["status"]),
+			TransactionID: values["transaction_id"],
+			Message:       values["message"],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Layout tham khảo cho file chuyển lương của Vietcombank
+var VietcombankFormat = FixedWidthFormat{
+	FormatName: "vcb",
+	DateLayout: "20060102",
+	Header: []Field{
+		{Name: "record_type", Width: 1, Value: "H"},
+		{Name: "batch_reference", Width: 20},
+		{Name: "debit_account", Width: 20},
+		{Name: "value_date", Width: 8},
+		{Name: "record_count", Width: 6, Numeric: true},
+		{Name: "total_amount", Width: 18, Numeric: true},
+	},
+	Detail: []Field{
+		{Name: "record_type", Width: 1, Value: "D"},
+		{Name: "sequence", Width: 6, Numeric: true},
+		{Name: "reference", Width: 20},
+		{Name: "bank_code", Width: 8},
+		{Name: "account_number", Width: 20},
+		{Name: "account_holder", Width: 50},
+		{Name: "amount", Width: 18, Numeric: true},
+		{Name: "description", Width: 100, Truncate: true},
+	},
+	Trailer: []Field{
+		{Name: "record_type", Width: 1, Value: "T"},
+		{Name: "record_count", Width: 6, Numeric: true},
+		{Name: "total_amount", Width: 18, Numeric: true},
+	},
+	Result: []Field{
+		{Name: "record_type", Width: 1, Value: "D"},
+		{Name: "reference", Width: 20},
+		{Name: "account_number", Width: 20},
+		{Name: "amount", Width: 18, Numeric: true},
+		{Name: "status", Width: 2},
+		{Name: "transaction_id", Width: 20},
+		{Name: "message", Width: 100},
+	},
+}
+
+// Layout tham khảo cho file chi lương của BIDV
+var BIDVFormat = FixedWidthFormat{
+	FormatName: "bidv",
+	DateLayout: "02012006",
+	Header: []Field{
+		{Name: "record_type", Width: 2, Value: "01"},
+		{Name: "debit_account", Width: 14},
+		{Name: "value_date", Width: 8},
+		{Name: "batch_reference", Width: 16},
+		{Name: "record_count", Width: 5, Numeric: true},
+		{Name: "total_amount", Width: 15, Numeric: true},
+	},
+	Detail: []Field{
+		{Name: "record_type", Width: 2, Value: "02"},
+		{Name: "reference", Width: 16},
+		{Name: "account_number", Width: 14},
+		{Name: "account_holder", Width: 40},
+		{Name: "bank_code", Width: 8},
+		{Name: "amount", Width: 15, Numeric: true},
+		{Name: "description", Width: 60, Truncate: true},
+	},
+	Result: []Field{
+		{Name: "record_type", Width: 2, Value: "02"},
+		{Name: "reference", Width: 16},
+		{Name: "account_number", Width: 14},
+		{Name: "amount", Width: 15, Numeric: true},
+		{Name: "status", Width: 2},
+		{Name: "transaction_id", Width: 16},
+		{Name: "message", Width: 60},
+	},
+}
+
+func init() {
+	Register(VietcombankFormat)
+	Register(BIDVFormat)
+}
@@ -0,0 +1,89 @@
+package calendar
+
+import (
+	"sort"
+	"time"
+)
+
+// Holiday là một ngày nghỉ lễ
+type Holiday struct {
+	Date       time.Time
+	Name       string
+	Substitute bool // Ngày nghỉ bù khi ngày lễ trùng ngày nghỉ hằng tuần
+}
+
+// solarDay tạo ngày dương lịch theo giờ địa phương
+func solarDay(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// StatutoryHolidays sinh các ngày nghỉ lễ theo Điều 112 Bộ luật Lao động 2019 cho năm dương lịch:
+// Tết Dương lịch, Tết Âm lịch (5 ngày: ngày cuối năm cũ và 4 ngày đầu năm mới),
+// Giỗ Tổ Hùng Vương (10/3 âm lịch), 30/4, 1/5 và Quốc khánh (1/9 và 2/9).
+func StatutoryHolidays(year int) ([]Holiday, error) {
+	holidays := []Holiday{
+		{Date: solarDay(year, time.January, 1), Name: "Tết Dương lịch"},
+		{Date: solarDay(year, time.April, 30), Name: "Ngày Giải phóng miền Nam"},
+		{Date: solarDay(year, time.May, 1), Name: "Ngày Quốc tế Lao động"},
+		{Date: solarDay(year, time.September, 1), Name: "Quốc khánh"},
+		{Date: solarDay(year, time.September, 2), Name: "Quốc khánh"},
+	}
+
+	tet, err := LunarToSolar(LunarDate{Day: 1, Month: 1, Year: year})
+	if err != nil {
+		return nil, err
+	}
+	holidays = append(holidays, Holiday{Date: tet.AddDate(0, 0, -1), Name: "Tết Nguyên đán (Giao thừa)"})
+	for i := 0; i < 4; i++ {
+		holidays = append(holidays, Holiday{Date: tet.AddDate(0, 0, i), Name: "Tết Nguyên đán"})
+	}
+
+	hungKings, err := LunarToSolar(LunarDate{Day: 10, Month: 3, Year: year})
+	if err != nil {
+		return nil, err
+	}
+	holidays = append(holidays, Holiday{Date: hungKings, Name: "Giỗ Tổ Hùng Vương"})
+
+	sortHolidays(holidays)
+	return holidays, nil
+}
+
+// WithSubstitutes thêm ngày nghỉ bù cho các ngày lễ trùng ngày nghỉ hằng tuần.
+// Ngày nghỉ bù là ngày làm việc kế tiếp chưa phải ngày lễ hoặc ngày nghỉ bù khác.
+// occupied chứa các ngày nghỉ khác (ví dụ ngày nghỉ riêng của công ty) không được dùng làm ngày bù.
+func WithSubstitutes(holidays []Holiday, isWorkday func(time.Time) bool, occupied map[string]bool) []Holiday {
+	taken := map[string]bool{}
+	for key := range occupied {
+		taken[key] = true
+	}
+	for _, h := range holidays {
+		taken[DateKey(h.Date)] = true
+	}
+
+	result := append([]Holiday{}, holidays...)
+	for _, h := range holidays {
+		if isWorkday(h.Date) {
+			continue
+		}
+		day := h.Date.AddDate(0, 0, 1)
+		for !isWorkday(day) || taken[DateKey(day)] {
+			day = day.AddDate(0, 0, 1)
+		}
+		taken[DateKey(day)] = true
+		result = append(result, Holiday{Date: day, Name: "Nghỉ bù " + h.Name, Substitute: true})
+	}
+
+	sortHolidays(result)
+	return result
+}
+
+// DateKey trả về chuỗi "YYYY-MM-DD" dùng làm khóa tra cứu theo ngày
+func DateKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func sortHolidays(holidays []Holiday) {
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+}
@@ -0,0 +1,222 @@
+// Package calendar chuyển đổi âm lịch - dương lịch và sinh lịch nghỉ lễ của Việt Nam.
+//
+// Thuật toán chuyển đổi âm lịch dựa trên công trình của Hồ Ngọc Đức, tính theo
+// múi giờ Hà Nội (UTC+7) nên trùng với lịch âm chính thức của Việt Nam.
+package calendar
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// vietnamTimeZone là múi giờ dùng để tính ngày sóc và trung khí
+const vietnamTimeZone = 7.0
+
+// LunarDate là một ngày âm lịch
+type LunarDate struct {
+	Day   int  `json:"day"`
+	Month int  `json:"month"`
+	Year  int  `json:"year"`
+	Leap  bool `json:"leap"` // Tháng nhuận
+}
+
+// jdFromDate đổi ngày dương lịch sang số ngày Julius
+func jdFromDate(dd, mm, yy int) int {
+	a := (14 - mm) / 12
+	y := yy + 4800 - a
+	m := mm + 12*a - 3
+	jd := dd + (153*m+2)/5 + 365*y + y/4 - y/100 + y/400 - 32045
+	if jd < 2299161 {
+		jd = dd + (153*m+2)/5 + 365*y + y/4 - 32083
+	}
+	return jd
+}
+
+// jdToDate đổi số ngày Julius sang ngày dương lịch
+func jdToDate(jd int) (day, month, year int) {
+	var b, c int
+	if jd > 2299160 {
+		a := jd + 32044
+		b = (4*a + 3) / 146097
+		c = a - (b*146097)/4
+	} else {
+		c = jd + 32082
+	}
+	d := (4*c + 3) / 1461
+	e := c - (1461*d)/4
+	m := (5*e + 2) / 153
+	day = e - (153*m+2)/5 + 1
+	month = m + 3 - 12*(m/10)
+	year = b*100 + d - 4800 + m/10
+	return day, month, year
+}
+
+// newMoon tính thời điểm sóc thứ k kể từ 1/1/1900 (số ngày Julius, có phần lẻ)
+func newMoon(k int) float64 {
+	kf := float64(k)
+	t := kf / 1236.85
+	t2 := t * t
+	t3 := t2 * t
+	dr := math.Pi / 180
+
+	jd1 := 2415020.75933 + 29.53058868*kf + 0.0001178*t2 - 0.000000155*t3
+	jd1 += 0.00033 * math.Sin((166.56+132.87*t-0.009173*t2)*dr)
+	m := 359.2242 + 29.10535608*kf - 0.0000333*t2 - 0.00000347*t3
+	mpr := 306.0253 + 385.81691806*kf + 0.0107306*t2 + 0.00001236*t3
+	f := 21.2964 + 390.67050646*kf - 0.0016528*t2 - 0.00000239*t3
+
+	c1 := (0.1734-0.000393*t)*math.Sin(m*dr) + 0.0021*math.Sin(2*dr*m)
+	c1 -= 0.4068*math.Sin(mpr*dr) - 0.0161*math.Sin(dr*2*mpr)
+	c1 -= 0.0004 * math.Sin(dr*3*mpr)
+	c1 += 0.0104*math.Sin(dr*2*f) - 0.0051*math.Sin(dr*(m+mpr))
+	c1 -= 0.0074*math.Sin(dr*(m-mpr)) - 0.0004*math.Sin(dr*(2*f+m))
+	c1 -= 0.0004*math.Sin(dr*(2*f-m)) + 0.0006*math.Sin(dr*(2*f+mpr))
+	c1 += 0.0010*math.Sin(dr*(2*f-mpr)) + 0.0005*math.Sin(dr*(2*mpr+m))
+
+	var deltat float64
+	if t < -11 {
+		deltat = 0.001 + 0.000839*t + 0.0002261*t2 - 0.00000845*t3 - 0.000000081*t*t3
+	} else {
+		deltat = -0.000278 + 0.000265*t + 0.000262*t2
+	}
+	return jd1 + c1 - deltat
+}
+
+// sunLongitude tính kinh độ mặt trời (radian) tại thời điểm jdn
+func sunLongitude(jdn float64) float64 {
+	t := (jdn - 2451545.0) / 36525
+	t2 := t * t
+	dr := math.Pi / 180
+
+	m := 357.52910 + 35999.05030*t - 0.0001559*t2 - 0.00000048*t*t2
+	l0 := 280.46645 + 36000.76983*t + 0.0003032*t2
+	dl := (1.914600 - 0.004817*t - 0.000014*t2) * math.Sin(dr*m)
+	dl += (0.019993-0.000101*t)*math.Sin(dr*2*m) + 0.000290*math.Sin(dr*3*m)
+
+	l := (l0 + dl) * dr
+	return l - math.Pi*2*math.Floor(l/(math.Pi*2))
+}
+
+// newMoonDay trả về số ngày Julius của ngày sóc thứ k theo giờ địa phương
+func newMoonDay(k int) int {
+	return int(math.Floor(newMoon(k) + 0.5 + vietnamTimeZone/24))
+}
+
+// sunLongitudeSector trả về cung (0-11) của kinh độ mặt trời lúc bắt đầu ngày
+func sunLongitudeSector(dayNumber int) int {
+	return int(math.Floor(sunLongitude(float64(dayNumber)-0.5-vietnamTimeZone/24) / math.Pi * 6))
+}
+
+// lunarMonth11 tìm ngày bắt đầu tháng 11 âm lịch (tháng chứa Đông chí) của năm yy
+func lunarMonth11(yy int) int {
+	off := jdFromDate(31, 12, yy) - 2415021
+	k := int(math.Floor(float64(off) / 29.530588853))
+	nm := newMoonDay(k)
+	if sunLongitudeSector(nm) >= 9 {
+		nm = newMoonDay(k - 1)
+	}
+	return nm
+}
+
+// leapMonthOffset xác định vị trí tháng nhuận sau tháng 11 âm lịch bắt đầu ở a11
+func leapMonthOffset(a11 int) int {
+	k := int(math.Floor((float64(a11)-2415021.076998695)/29.530588853 + 0.5))
+	i := 1
+	arc := sunLongitudeSector(newMoonDay(k + i))
+	for {
+		last := arc
+		i++
+		arc = sunLongitudeSector(newMoonDay(k + i))
+		if arc == last || i >= 14 {
+			break
+		}
+	}
+	return i - 1
+}
+
+// SolarToLunar đổi ngày dương lịch sang âm lịch
+func SolarToLunar(date time.Time) LunarDate {
+	dd, mm, yy := date.Day(), int(date.Month()), date.Year()
+	dayNumber := jdFromDate(dd, mm, yy)
+
+	k := int(math.Floor((float64(dayNumber) - 2415021.076998695) / 29.530588853))
+	monthStart := newMoonDay(k + 1)
+	if monthStart > dayNumber {
+		monthStart = newMoonDay(k)
+	}
+
+	var lunar LunarDate
+	a11 := lunarMonth11(yy)
+	b11 := a11
+	if a11 >= monthStart {
+		lunar.Year = yy
+		a11 = lunarMonth11(yy - 1)
+	} else {
+		lunar.Year = yy + 1
+		b11 = lunarMonth11(yy + 1)
+	}
+
+	lunar.Day = dayNumber - monthStart + 1
+	diff := (monthStart - a11) / 29
+	lunar.Month = diff + 11
+	if b11-a11 > 365 {
+		leapDiff := leapMonthOffset(a11)
+		if diff >= leapDiff {
+			lunar.Month = diff + 10
+			lunar.Leap = diff == leapDiff
+		}
+	}
+	if lunar.Month > 12 {
+		lunar.Month -= 12
+	}
+	if lunar.Month >= 11 && diff < 4 {
+		lunar.Year--
+	}
+	return lunar
+}
+
+// LunarToSolar đổi ngày âm lịch sang dương lịch (giờ địa phương, 0h)
+func LunarToSolar(lunar LunarDate) (time.Time, error) {
+	if lunar.Month < 1 || lunar.Month > 12 || lunar.Day < 1 || lunar.Day > 30 {
+		return time.Time{}, fmt.Errorf("invalid lunar date %d/%d/%d", lunar.Day, lunar.Month, lunar.Year)
+	}
+
+	var a11, b11 int
+	if lunar.Month < 11 {
+		a11 = lunarMonth11(lunar.Year - 1)
+		b11 = lunarMonth11(lunar.Year)
+	} else {
+		a11 = lunarMonth11(lunar.Year)
+		b11 = lunarMonth11(lunar.Year + 1)
+	}
+
+	k := int(math.Floor(0.5 + (float64(a11)-2415021.076998695)/29.530588853))
+	off := lunar.Month - 11
+	if off < 0 {
+		off += 12
+	}
+	if b11-a11 > 365 {
+		leapOff := leapMonthOffset(a11)
+		leapMonth := leapOff - 2
+		if leapMonth < 0 {
+			leapMonth += 12
+		}
+		if lunar.Leap && lunar.Month != leapMonth {
+			return time.Time{}, fmt.Errorf("lunar year %d has no leap month %d", lunar.Year, lunar.Month)
+		}
+		if lunar.Leap || off >= leapOff {
+			off++
+		}
+	} else if lunar.Leap {
+		return time.Time{}, fmt.Errorf("lunar year %d has no leap month", lunar.Year)
+	}
+
+	// Tháng âm lịch dài 29 hoặc 30 ngày, kết thúc trước ngày sóc kế tiếp
+	monthStart := newMoonDay(k + off)
+	if days := newMoonDay(k+off+1) - monthStart; lunar.Day > days {
+		return time.Time{}, fmt.Errorf("lunar month %d/%d has only %d days", lunar.Month, lunar.Year, days)
+	}
+	d, m, y := jdToDate(monthStart + lunar.Day - 1)
+	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.Local), nil
+}
@@ -0,0 +1,64 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLunarToSolarKnownHolidays(t *testing.T) {
+	tests := []struct {
+		name  string
+		lunar LunarDate
+		want  string
+	}{
+		{"Tết 2023", LunarDate{Day: 1, Month: 1, Year: 2023}, "2023-01-22"},
+		{"Tết 2024", LunarDate{Day: 1, Month: 1, Year: 2024}, "2024-02-10"},
+		{"Tết 2025", LunarDate{Day: 1, Month: 1, Year: 2025}, "2025-01-29"},
+		{"Tết 2026", LunarDate{Day: 1, Month: 1, Year: 2026}, "2026-02-17"},
+		{"Hùng Kings 2023, after leap month 2", LunarDate{Day: 10, Month: 3, Year: 2023}, "2023-04-29"},
+		{"Hùng Kings 2024", LunarDate{Day: 10, Month: 3, Year: 2024}, "2024-04-18"},
+		{"Hùng Kings 2025", LunarDate{Day: 10, Month: 3, Year: 2025}, "2025-04-07"},
+		{"Hùng Kings 2026", LunarDate{Day: 10, Month: 3, Year: 2026}, "2026-04-26"},
+		{"30 Tết of a 30-day month", LunarDate{Day: 30, Month: 12, Year: 2023}, "2024-02-09"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LunarToSolar(tt.lunar)
+			if err != nil {
+				t.Fatalf("LunarToSolar(%+v) error = %v", tt.lunar, err)
+			}
+			if got.Format("2006-01-02") != tt.want {
+				t.Errorf("LunarToSolar(%+v) = %s, want %s", tt.lunar, got.Format("2006-01-02"), tt.want)
+			}
+			if back := SolarToLunar(got); back != tt.lunar {
+				t.Errorf("SolarToLunar(%s) = %+v, want %+v", tt.want, back, tt.lunar)
+			}
+		})
+	}
+}
+
+func TestLunarToSolarRejectsInvalidDates(t *testing.T) {
+	for _, lunar := range []LunarDate{
+		{Day: 30, Month: 1, Year: 2024},  // Tháng Giêng 2024 chỉ có 29 ngày
+		{Day: 30, Month: 12, Year: 2024}, // Tháng Chạp 2024 chỉ có 29 ngày
+		{Day: 31, Month: 5, Year: 2024},
+		{Day: 0, Month: 5, Year: 2024},
+		{Day: 1, Month: 13, Year: 2024},
+		{Day: 1, Month: 2, Year: 2024, Leap: true}, // 2024 không có tháng nhuận
+		{Day: 1, Month: 3, Year: 2023, Leap: true}, // 2023 nhuận tháng 2, không phải tháng 3
+	} {
+		if got, err := LunarToSolar(lunar); err == nil {
+			t.Errorf("LunarToSolar(%+v) = %s, want error", lunar, got.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestLunarToSolarLeapMonth(t *testing.T) {
+	got, err := LunarToSolar(LunarDate{Day: 1, Month: 2, Year: 2023, Leap: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2023, time.March, 22, 0, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("1/2 nhuận 2023 = %s, want %s", got.Format("2006-01-02"), want.Format("2006-01-02"))
+	}
+}
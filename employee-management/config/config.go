@@ -19,3 +19,11 @@ func LoadEnv() error {
 func GetEnv(key string) string {
 	return os.Getenv(key)
 }
+
+// GetEnvDefault trả về giá trị biến môi trường, hoặc fallback nếu chưa được thiết lập
+func GetEnvDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -1,21 +1,166 @@
 package controllers
 
 import (
+	"employee-management/calendar"
 	"employee-management/config"
 	"employee-management/models"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-// Lấy danh sách công tác
-func GetWorkAssignments(c *gin.Context) {
+// WorkAssignmentResponse là công tác kèm thông tin rút gọn của nhân viên được giao
+type WorkAssignmentResponse struct {
+	models.WorkAssignment
+	Employee EmployeeSummary    `json:"employee"`
+	Warnings []ScheduleConflict `json:"warnings,omitempty"` // Lịch trùng khi tạo hoặc sửa công tác với WORK_ASSIGNMENT_OVERLAP_POLICY=warn
+}
+
+// WorkAssignmentPage là một trang trong danh sách công tác
+type WorkAssignmentPage struct {
+	Data     []WorkAssignmentResponse `json:"data"`
+	Page     int                      `json:"page"`
+	PageSize int                      `json:"page_size"`
+	Total    int64                    `json:"total"`
+}
+
+// newWorkAssignmentResponse gắn thông tin rút gọn của nhân viên vào công tác
+func newWorkAssignmentResponse(assignment models.WorkAssignment, employee models.Employee) WorkAssignmentResponse {
+	return WorkAssignmentResponse{WorkAssignment: assignment, Employee: summarizeEmployee(employee)}
+}
+
+// filterWorkAssignments thêm các điều kiện lọc department_id, status, from, to vào truy vấn.
+// Khoảng from/to lấy các công tác giao với khoảng đó; công tác không có ngày kết thúc chỉ tính ngày bắt đầu.
+func filterWorkAssignments(c *gin.Context, query *gorm.DB) (*gorm.DB, bool) {
+	if departmentID := c.Query("department_id"); departmentID != "" {
+		id, err := strconv.Atoi(departmentID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+			return query, false
+		}
+		query = query.Where("employee_id IN (?)",
+			activeOn(config.GetDB().Table("employee_departments").Select("employee_id").Where("department_id = ?", id), "employee_departments", time.Now()))
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var from, to time.Time
+	var err error
+	if value := c.Query("from"); value != "" {
+		if from, err = time.ParseInLocation("2006-01-02", value, time.Local); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be in YYYY-MM-DD format"})
+			return query, false
+		}
+		// end_date lưu dạng timestamp, giá trị trước năm 1900 nghĩa là không có ngày kết thúc
+		query = query.Where("end_date >= ? OR (end_date < ? AND start_date >= ?)", calendar.DateKey(from), "1900-01-01", calendar.DateKey(from))
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = time.ParseInLocation("2006-01-02", value, time.Local); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be in YYYY-MM-DD format"})
+			return query, false
+		}
+		if !from.IsZero() && to.Before(from) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must not be before from"})
+			return query, false
+		}
+		query = query.Where("start_date < ?", calendar.DateKey(to.AddDate(0, 0, 1)))
+	}
+	return query, true
+}
+
+// listWorkAssignments trả về một trang công tác theo truy vấn đã lọc, mới nhất trước
+func listWorkAssignments(c *gin.Context, query *gorm.DB) {
+	page, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	// Session cho phép dùng lại truy vấn đã lọc cho cả Count và Find
+	query = query.Session(&gorm.Session{})
+	var total int64
+	if err := query.Model(&models.WorkAssignment{}).Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch work assignments"})
+		return
+	}
+
 	var workAssignments []models.WorkAssignment
-	if err := config.GetDB().Preload("Employee").Find(&workAssignments).Error; err != nil {
+	if err := page.apply(query.Preload("Employee")).Order("start_date DESC, id DESC").Find(&workAssignments).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch work assignments"})
 		return
 	}
-	c.JSON(http.StatusOK, workAssignments)
+
+	result := WorkAssignmentPage{Data: make([]WorkAssignmentResponse, 0, len(workAssignments)), Page: page.Page, PageSize: page.PageSize, Total: total}
+	for _, assignment := range workAssignments {
+		result.Data = append(result.Data, newWorkAssignmentResponse(assignment, assignment.Employee))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetWorkAssignments godoc
+// @Summary Get work assignments
+// @Description List work assignments page by page, filtered by employee, department, status and a date range the assignment overlaps
+// @Tags WorkAssignment
+// @Produce json
+// @Param employee_id query int false "Employee ID"
+// @Param department_id query int false "Department ID"
+// @Param status query string false "Status (planned/in_progress/completed/cancelled/overdue)"
+// @Param from query string false "Overlapping from date (YYYY-MM-DD)"
+// @Param to query string false "Overlapping to date (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size (max 100)" default(20)
+// @Success 200 {object} WorkAssignmentPage
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workassignments [get]
+func GetWorkAssignments(c *gin.Context) {
+	query := config.GetDB().Model(&models.WorkAssignment{})
+	if employeeID := c.Query("employee_id"); employeeID != "" {
+		id, err := strconv.Atoi(employeeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+			return
+		}
+		query = query.Where("employee_id = ?", id)
+	}
+	query, ok := filterWorkAssignments(c, query)
+	if !ok {
+		return
+	}
+	listWorkAssignments(c, query)
+}
+
+// GetEmployeeWorkAssignments godoc
+// @Summary Get work assignments of an employee
+// @Description List an employee's work assignments page by page, filtered by status and date range
+// @Tags WorkAssignment
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param status query string false "Status (planned/in_progress/completed/cancelled/overdue)"
+// @Param from query string false "Overlapping from date (YYYY-MM-DD)"
+// @Param to query string false "Overlapping to date (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size (max 100)" default(20)
+// @Success 200 {object} WorkAssignmentPage
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/workassignments [get]
+func GetEmployeeWorkAssignments(c *gin.Context) {
+	var employee models.Employee
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&employee).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+		return
+	}
+
+	query, ok := filterWorkAssignments(c, config.GetDB().Model(&models.WorkAssignment{}).Where("employee_id = ?", employee.ID))
+	if !ok {
+		return
+	}
+	listWorkAssignments(c, query)
 }
 
 // Thêm một công tác mới
@@ -35,11 +180,29 @@ func CreateWorkAssignment(c *gin.Context) {
 		return
 	}
 
-	// Gán EmployeeName từ nhân viên và đảm bảo Status có giá trị mặc định
+	// Gán EmployeeName từ nhân viên, công tác mới luôn bắt đầu ở trạng thái planned
 	request.EmployeeName = employee.Name
-	if request.Status == "" {
-		request.Status = "Chưa hoàn thành"
+	request.Status = models.AssignmentPlanned
+	request.Progress = 0
+	request.CompletedAt = nil
+	request.History = nil
+
+	// Kiểm tra ngày và lịch trùng của nhân viên
+	if err := validateAssignmentDates(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	conflicts, shifts, err := assignmentConflicts(request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check schedule conflicts"})
+		return
+	}
+	if len(conflicts) > 0 && overlapPolicy() == OverlapBlock {
+		c.JSON(http.StatusConflict, gin.H{"error": "Work assignment overlaps existing schedules", "conflicts": conflicts})
+		return
 	}
+	warnings := append(conflicts, shifts...)
+	writeConflictWarnings(c, warnings)
 
 	// Lưu công việc mới vào cơ sở dữ liệu
 	if err := config.GetDB().Create(&request).Error; err != nil {
@@ -47,11 +210,18 @@ func CreateWorkAssignment(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Work assignment created successfully", "data": request})
+	response := newWorkAssignmentResponse(request, employee)
+	response.Warnings = warnings
+	c.JSON(http.StatusOK, gin.H{"message": "Work assignment created successfully", "data": response})
 }
 
 // Cập nhật công tác
 func UpdateWorkAssignment(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
 	var workAssignment models.WorkAssignment
 	id := c.Param("id")
 
@@ -60,20 +230,72 @@ func UpdateWorkAssignment(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Work assignment not found"})
 		return
 	}
+	if !canManageAssignment(actor, workAssignment) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the assignee or a manager can change this work assignment"})
+		return
+	}
+	// Công tác đã hoàn thành hoặc đã hủy không được sửa
+	if workAssignment.Status == models.AssignmentCompleted || workAssignment.Status == models.AssignmentCancelled {
+		c.JSON(http.StatusConflict, gin.H{"error": "Completed or cancelled work assignments cannot be updated"})
+		return
+	}
 
 	// Liên kết dữ liệu JSON mới với công tác
+	assignmentID, status, progress, notes, completedAt := workAssignment.ID, workAssignment.Status, workAssignment.Progress, workAssignment.Notes, workAssignment.CompletedAt
 	if err := c.ShouldBindJSON(&workAssignment); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
 		return
 	}
+	// Luôn ghi vào công tác theo ID trên đường dẫn, người được giao không được chuyển công tác cho người khác
+	workAssignment.ID = assignmentID
+	if !canManageAssignment(actor, workAssignment) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only a manager can reassign this work assignment"})
+		return
+	}
+	// Trạng thái và tiến độ chỉ thay đổi qua các thao tác chuyển trạng thái
+	workAssignment.Status = status
+	workAssignment.Progress = progress
+	workAssignment.Notes = notes
+	workAssignment.CompletedAt = completedAt
+	workAssignment.History = nil
 
-	// Lưu thông tin công tác đã cập nhật vào cơ sở dữ liệu
-	if err := config.GetDB().Save(&workAssignment).Error; err != nil {
+	// Kiểm tra ngày và lịch trùng của nhân viên
+	if err := validateAssignmentDates(workAssignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	conflicts, shifts, err := assignmentConflicts(workAssignment)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check schedule conflicts"})
+		return
+	}
+	if len(conflicts) > 0 && overlapPolicy() == OverlapBlock {
+		c.JSON(http.StatusConflict, gin.H{"error": "Work assignment overlaps existing schedules", "conflicts": conflicts})
+		return
+	}
+	warnings := append(conflicts, shifts...)
+	writeConflictWarnings(c, warnings)
+
+	// Lưu thông tin công tác đã cập nhật; công tác quá hạn được dời ngày kết thúc thì tiếp tục như trước
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := resumeOverdueAssignment(tx, &workAssignment); err != nil {
+			return err
+		}
+		return tx.Save(&workAssignment).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update work assignment"})
 		return
 	}
 
-	c.JSON(http.StatusOK, workAssignment)
+	var employee models.Employee
+	if err := config.GetDB().First(&employee, workAssignment.EmployeeID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+		return
+	}
+	response := newWorkAssignmentResponse(workAssignment, employee)
+	response.Warnings = warnings
+	c.JSON(http.StatusOK, response)
 }
 
 // Xóa công tác
@@ -87,6 +309,17 @@ func DeleteWorkAssignment(c *gin.Context) {
 		return
 	}
 
+	// Không xóa công tác đã có chi phí đề nghị hoàn trả
+	var claims int64
+	if err := config.GetDB().Model(&models.ExpenseClaim{}).Where("work_assignment_id = ?", workAssignment.ID).Count(&claims).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check expense claims"})
+		return
+	}
+	if claims > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Work assignment has expense claims and cannot be deleted"})
+		return
+	}
+
 	// Xóa công tác khỏi cơ sở dữ liệu
 	if err := config.GetDB().Delete(&workAssignment).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete work assignment"})
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActorHeader là header chứa ID của nhân viên đang thực hiện thao tác
+const ActorHeader = "X-Employee-ID"
+
+// Nhóm vai trò được phép thực hiện các thao tác nghiệp vụ
+var (
+	payrollRoles   = []string{"Payroll", "Admin"}
+	hrManagerRoles = []string{"HR Manager", "Admin"}
+	financeRoles   = []string{"Finance", "Admin"}
+	managerRoles   = []string{"Manager", "HR Manager", "Admin"}
+)
+
+// currentActor lấy nhân viên thực hiện thao tác từ header X-Employee-ID.
+// Nếu không hợp lệ, hàm tự trả lỗi về client và trả về false.
+func currentActor(c *gin.Context) (models.Employee, bool) {
+	var actor models.Employee
+
+	id, err := strconv.Atoi(c.GetHeader(ActorHeader))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Missing or invalid " + ActorHeader + " header"})
+		return actor, false
+	}
+
+	if err := config.GetDB().First(&actor, id).Error; err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Actor not found"})
+		return actor, false
+	}
+
+	return actor, true
+}
+
+// hasRole kiểm tra nhân viên có một trong các vai trò được phép hay không
+func hasRole(employee models.Employee, roles ...string) bool {
+	for _, role := range roles {
+		if employee.Role == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,480 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SalaryAdvanceInput là dữ liệu nhân viên gửi khi xin tạm ứng lương
+type SalaryAdvanceInput struct {
+	Amount       int    `json:"amount" binding:"required"`
+	Installments int    `json:"installments"` // Mặc định trừ hết trong một kỳ
+	Reason       string `json:"reason"`
+}
+
+// DisburseAdvanceInput là thông tin chi tiền tạm ứng
+type DisburseAdvanceInput struct {
+	Reference           string `json:"reference"`
+	FirstDeductionYear  int    `json:"first_deduction_year"`  // Mặc định là kỳ lương của tháng chi tiền
+	FirstDeductionMonth int    `json:"first_deduction_month"` // Mặc định là kỳ lương của tháng chi tiền
+}
+
+// AdvanceBalance là số dư tạm ứng còn phải trừ của nhân viên
+type AdvanceBalance struct {
+	EmployeeID  uint                   `json:"employee_id"`
+	Outstanding int                    `json:"outstanding"` // Đã chi nhưng chưa trừ hết
+	Committed   int                    `json:"committed"`   // Đang chờ duyệt hoặc đã duyệt nhưng chưa chi
+	Limit       int                    `json:"limit"`       // Hạn mức tạm ứng theo lương cơ bản hiện tại
+	Available   int                    `json:"available"`
+	Advances    []models.SalaryAdvance `json:"advances"`
+}
+
+// advanceMaxPercent là tỷ lệ tối đa của lương cơ bản được tạm ứng, cấu hình qua ADVANCE_MAX_PERCENT
+func advanceMaxPercent() int {
+	if percent, err := strconv.Atoi(config.GetEnv("ADVANCE_MAX_PERCENT")); err == nil && percent > 0 {
+		return percent
+	}
+	return 50
+}
+
+// advanceMaxInstallments là số kỳ trừ dần tối đa, cấu hình qua ADVANCE_MAX_INSTALLMENTS
+func advanceMaxInstallments() int {
+	if installments, err := strconv.Atoi(config.GetEnv("ADVANCE_MAX_INSTALLMENTS")); err == nil && installments > 0 {
+		return installments
+	}
+	return 6
+}
+
+// currentBaseSalary lấy lương cơ bản đã duyệt đang có hiệu lực của nhân viên
+func currentBaseSalary(employeeID uint) (int, error) {
+	rates, err := approvedRates(employeeID, time.Now())
+	if err != nil || len(rates) == 0 {
+		return 0, err
+	}
+	return rates[len(rates)-1].Amount, nil
+}
+
+// computeAdvanceBalance tính số dư tạm ứng và hạn mức còn lại của nhân viên
+func computeAdvanceBalance(employeeID uint) (AdvanceBalance, error) {
+	balance := AdvanceBalance{EmployeeID: employeeID}
+
+	if err := config.GetDB().Preload("Repayments").
+		Where("employee_id = ?", employeeID).
+		Order("id DESC").
+		Find(&balance.Advances).Error; err != nil {
+		return balance, err
+	}
+	for _, advance := range balance.Advances {
+		switch advance.Status {
+		case models.AdvanceDisbursed:
+			balance.Outstanding += advance.Amount - advance.RepaidAmount
+		case models.RequestPending, models.RequestApproved:
+			balance.Committed += advance.Amount
+		}
+	}
+
+	base, err := currentBaseSalary(employeeID)
+	if err != nil {
+		return balance, err
+	}
+	balance.Limit = base * advanceMaxPercent() / 100
+	balance.Available = balance.Limit - balance.Outstanding - balance.Committed
+	if balance.Available < 0 {
+		balance.Available = 0
+	}
+	return balance, nil
+}
+
+// installmentAmount tính số tiền trừ ở kỳ tiếp theo; kỳ cuối trừ toàn bộ phần còn lại
+func installmentAmount(advance models.SalaryAdvance) int {
+	remaining := advance.Amount - advance.RepaidAmount
+	installmentsLeft := advance.Installments - len(advance.Repayments)
+	if remaining <= 0 {
+		return 0
+	}
+	if installmentsLeft <= 1 {
+		return remaining
+	}
+	if amount := remaining / installmentsLeft; amount > 0 {
+		return amount
+	}
+	return remaining
+}
+
+// advanceDeductions tính các khoản trừ tạm ứng cho kỳ lương, tổng không vượt quá limit
+func advanceDeductions(employeeID uint, year, month, limit int) ([]models.AdvanceRepayment, int, error) {
+	var advances []models.SalaryAdvance
+	if err := config.GetDB().Preload("Repayments").
+		Where("employee_id = ? AND status = ? AND first_deduction_year * 12 + first_deduction_month <= ?",
+			employeeID, models.AdvanceDisbursed, year*12+month).
+		Order("disbursed_at, id").
+		Find(&advances).Error; err != nil {
+		return nil, 0, err
+	}
+
+	repayments := []models.AdvanceRepayment{}
+	total := 0
+	for _, advance := range advances {
+		amount := installmentAmount(advance)
+		if amount > limit-total {
+			amount = limit - total
+		}
+		if amount <= 0 {
+			continue
+		}
+		repayments = append(repayments, models.AdvanceRepayment{
+			SalaryAdvanceID: advance.ID,
+			Amount:          amount,
+			PeriodYear:      year,
+			PeriodMonth:     month,
+		})
+		total += amount
+	}
+	return repayments, total, nil
+}
+
+// applyAdvanceRepayments lưu các khoản trừ tạm ứng của bảng lương và cập nhật số đã trả
+func applyAdvanceRepayments(tx *gorm.DB, salaryID uint, repayments []models.AdvanceRepayment) error {
+	for i := range repayments {
+		repayments[i].SalaryID = salaryID
+		if err := tx.Create(&repayments[i]).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.SalaryAdvance{}).Where("id = ?", repayments[i].SalaryAdvanceID).
+			Updates(map[string]interface{}{
+				"repaid_amount": gorm.Expr("repaid_amount + ?", repayments[i].Amount),
+				"status":        gorm.Expr("CASE WHEN repaid_amount + ? >= amount THEN ? ELSE status END", repayments[i].Amount, models.AdvanceRepaid),
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requireDraftSalary báo lỗi khi bảng lương không còn ở bước nháp
+func requireDraftSalary(tx *gorm.DB, salaryID uint) error {
+	var count int64
+	if err := tx.Model(&models.Salary{}).Where("id = ? AND approval_status = ?", salaryID, models.SalaryDraft).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("salary %d is not a draft", salaryID)
+	}
+	return nil
+}
+
+// revertAdvanceRepayments hoàn lại các khoản trừ tạm ứng khi bảng lương nháp bị xóa.
+// Khoản trừ của bảng lương đã duyệt hoặc đã trả là tiền đã thu, hoàn lại sẽ trừ lần nữa ở bảng lương sau.
+func revertAdvanceRepayments(tx *gorm.DB, salaryID uint) error {
+	if err := requireDraftSalary(tx, salaryID); err != nil {
+		return err
+	}
+	var repayments []models.AdvanceRepayment
+	if err := tx.Where("salary_id = ?", salaryID).Find(&repayments).Error; err != nil {
+		return err
+	}
+	for _, repayment := range repayments {
+		if err := tx.Model(&models.SalaryAdvance{}).Where("id = ?", repayment.SalaryAdvanceID).
+			Updates(map[string]interface{}{
+				"repaid_amount": gorm.Expr("repaid_amount - ?", repayment.Amount),
+				"status":        models.AdvanceDisbursed,
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Where("salary_id = ?", salaryID).Delete(&models.AdvanceRepayment{}).Error
+}
+
+// checkAdvanceLimit kiểm tra khoản tạm ứng không vượt quá hạn mức còn lại.
+// excludeAmount là số tiền của khoản đang được duyệt, đã nằm trong phần Committed.
+func checkAdvanceLimit(employeeID uint, amount int, excludeAmount int) error {
+	balance, err := computeAdvanceBalance(employeeID)
+	if err != nil {
+		return err
+	}
+	if balance.Limit == 0 {
+		return fmt.Errorf("Employee has no approved base salary to advance against")
+	}
+	available := balance.Limit - balance.Outstanding - balance.Committed + excludeAmount
+	if amount > available {
+		return fmt.Errorf("Advance exceeds the limit of %d%% of base salary: requested %d, available %d", advanceMaxPercent(), amount, available)
+	}
+	return nil
+}
+
+// GetSalaryAdvances godoc
+// @Summary Get salary advances
+// @Description List salary advances, optionally filtered by employee and status
+// @Tags Advance
+// @Produce json
+// @Param employee_id query int false "Employee ID"
+// @Param status query string false "Status (pending/approved/rejected/disbursed/repaid)"
+// @Success 200 {array} models.SalaryAdvance
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/advances [get]
+func GetSalaryAdvances(c *gin.Context) {
+	query := config.GetDB().Preload("Repayments")
+
+	if employeeID := c.Query("employee_id"); employeeID != "" {
+		id, err := strconv.Atoi(employeeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+			return
+		}
+		query = query.Where("employee_id = ?", id)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var advances []models.SalaryAdvance
+	if err := query.Order("id DESC").Find(&advances).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch salary advances"})
+		return
+	}
+	c.JSON(http.StatusOK, advances)
+}
+
+// CreateSalaryAdvance godoc
+// @Summary Request a salary advance
+// @Description The current employee requests an advance, repaid from later salaries in one or more installments. The total outstanding may not exceed ADVANCE_MAX_PERCENT of the base salary.
+// @Tags Advance
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryAdvanceInput true "Advance request"
+// @Success 201 {object} models.SalaryAdvance
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/advances [post]
+func CreateSalaryAdvance(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var input SalaryAdvanceInput
+	if err := c.ShouldBindJSON(&input); err != nil || input.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	if input.Installments == 0 {
+		input.Installments = 1
+	}
+	if input.Installments < 1 || input.Installments > advanceMaxInstallments() {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("installments must be between 1 and %d", advanceMaxInstallments())})
+		return
+	}
+
+	if err := checkAdvanceLimit(actor.ID, input.Amount, 0); err != nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	advance := models.SalaryAdvance{
+		EmployeeID:   actor.ID,
+		Amount:       input.Amount,
+		Installments: input.Installments,
+		Reason:       input.Reason,
+		Status:       models.RequestPending,
+	}
+	if err := config.GetDB().Create(&advance).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create salary advance"})
+		return
+	}
+	c.JSON(http.StatusCreated, advance)
+}
+
+// ApproveSalaryAdvance godoc
+// @Summary Approve a salary advance
+// @Description An HR manager approves a pending advance; the limit is checked again
+// @Tags Advance
+// @Accept json
+// @Produce json
+// @Param id path int true "Advance ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Optional comment"
+// @Success 200 {object} models.SalaryAdvance
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/advances/{id}/approve [post]
+func ApproveSalaryAdvance(c *gin.Context) {
+	reviewSalaryAdvance(c, models.RequestApproved)
+}
+
+// RejectSalaryAdvance godoc
+// @Summary Reject a salary advance
+// @Description An HR manager rejects a pending advance
+// @Tags Advance
+// @Accept json
+// @Produce json
+// @Param id path int true "Advance ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Optional comment"
+// @Success 200 {object} models.SalaryAdvance
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/advances/{id}/reject [post]
+func RejectSalaryAdvance(c *gin.Context) {
+	reviewSalaryAdvance(c, models.RequestRejected)
+}
+
+// reviewSalaryAdvance duyệt hoặc từ chối khoản tạm ứng đang chờ
+func reviewSalaryAdvance(c *gin.Context, status string) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can review salary advances"})
+		return
+	}
+
+	var body SalaryApprovalRequest
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	var advance models.SalaryAdvance
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&advance).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Salary advance not found"})
+		return
+	}
+	if advance.Status != models.RequestPending {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Salary advance is not pending"})
+		return
+	}
+	if advance.EmployeeID == actor.ID {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "You cannot review your own salary advance"})
+		return
+	}
+	if status == models.RequestApproved {
+		// Khoản đang duyệt đã được tính trong phần Committed
+		if err := checkAdvanceLimit(advance.EmployeeID, advance.Amount, advance.Amount); err != nil {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	now := time.Now()
+	advance.Status = status
+	advance.ReviewedByID = &actor.ID
+	advance.ReviewedAt = &now
+	advance.ReviewComment = body.Comment
+	if err := config.GetDB().Save(&advance).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update salary advance"})
+		return
+	}
+	c.JSON(http.StatusOK, advance)
+}
+
+// DisburseSalaryAdvance godoc
+// @Summary Record disbursement of a salary advance
+// @Description Finance records that an approved advance was paid out; deductions start from the given pay period, by default the month of disbursement
+// @Tags Advance
+// @Accept json
+// @Produce json
+// @Param id path int true "Advance ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body DisburseAdvanceInput false "Disbursement details"
+// @Success 200 {object} models.SalaryAdvance
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/advances/{id}/disburse [post]
+func DisburseSalaryAdvance(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, financeRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only finance can disburse salary advances"})
+		return
+	}
+
+	var input DisburseAdvanceInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	var advance models.SalaryAdvance
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&advance).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Salary advance not found"})
+		return
+	}
+	if advance.Status != models.RequestApproved {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Only approved salary advances can be disbursed"})
+		return
+	}
+
+	now := time.Now()
+	if input.FirstDeductionYear == 0 && input.FirstDeductionMonth == 0 {
+		input.FirstDeductionYear, input.FirstDeductionMonth = now.Year(), int(now.Month())
+	}
+	if input.FirstDeductionYear < 2000 || input.FirstDeductionMonth < 1 || input.FirstDeductionMonth > 12 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "A valid first_deduction_year and first_deduction_month (1-12) are required"})
+		return
+	}
+
+	advance.Status = models.AdvanceDisbursed
+	advance.DisbursedByID = &actor.ID
+	advance.DisbursedAt = &now
+	advance.DisbursementReference = input.Reference
+	advance.FirstDeductionYear = input.FirstDeductionYear
+	advance.FirstDeductionMonth = input.FirstDeductionMonth
+	if err := config.GetDB().Save(&advance).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to disburse salary advance"})
+		return
+	}
+	c.JSON(http.StatusOK, advance)
+}
+
+// GetEmployeeAdvanceBalance godoc
+// @Summary Get salary advance balance
+// @Description Get an employee's outstanding advance balance, remaining limit and advance history
+// @Tags Advance
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 200 {object} AdvanceBalance
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/advances [get]
+func GetEmployeeAdvanceBalance(c *gin.Context) {
+	var employee models.Employee
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&employee).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	balance, err := computeAdvanceBalance(employee.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to compute advance balance"})
+		return
+	}
+	c.JSON(http.StatusOK, balance)
+}
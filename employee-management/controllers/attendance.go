@@ -0,0 +1,520 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"employee-management/payroll"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// workSchedule đọc lịch làm việc chuẩn từ biến môi trường
+func workSchedule() (payroll.Schedule, error) {
+	grace, err := strconv.Atoi(config.GetEnvDefault("WORK_GRACE_MINUTES", "5"))
+	if err != nil {
+		grace = 0
+	}
+	return payroll.ParseSchedule(
+		config.GetEnvDefault("WORK_START", "08:00"),
+		config.GetEnvDefault("WORK_END", "17:00"),
+		grace,
+		config.GetEnvDefault("WORK_WEEKDAYS", "1,2,3,4,5"),
+	)
+}
+
+// dateOnly bỏ phần giờ của thời điểm
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// evaluateAttendance tính số phút đi muộn, về sớm và trạng thái của bản ghi chấm công
+func evaluateAttendance(record *models.AttendanceRecord, schedule payroll.Schedule) {
+	record.LateMinutes = 0
+	record.EarlyLeaveMinutes = 0
+	record.Status = models.AttendanceCheckedIn
+
+	// Ngày nghỉ theo lịch không tính đi muộn, về sớm
+	workday := schedule.IsWorkday(record.Date.Time)
+	if record.CheckIn != nil && workday {
+		record.LateMinutes = schedule.LateMinutes(*record.CheckIn)
+	}
+	if record.CheckOut != nil {
+		record.Status = models.AttendancePresent
+		if workday {
+			record.EarlyLeaveMinutes = schedule.EarlyLeaveMinutes(*record.CheckOut)
+		}
+	}
+}
+
+// CheckIn godoc
+// @Summary Check in
+// @Description Record the check-in time of the current employee for today
+// @Tags Attendance
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 201 {object} models.AttendanceRecord
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/attendance/check-in [post]
+func CheckIn(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := workSchedule()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Invalid work schedule configuration: " + err.Error()})
+		return
+	}
+
+	now := time.Now()
+	var existing models.AttendanceRecord
+	if err := config.GetDB().Where("employee_id = ? AND date = ?", actor.ID, now.Format("2006-01-02")).First(&existing).Error; err == nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Already checked in today"})
+		return
+	}
+
+	record := models.AttendanceRecord{
+		EmployeeID: actor.ID,
+		Date:       models.CustomTime{Time: dateOnly(now)},
+		CheckIn:    &now,
+	}
+	evaluateAttendance(&record, schedule)
+
+	if err := config.GetDB().Create(&record).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check in"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, record)
+}
+
+// CheckOut godoc
+// @Summary Check out
+// @Description Record the check-out time of the current employee for today
+// @Tags Attendance
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {object} models.AttendanceRecord
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/attendance/check-out [post]
+func CheckOut(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := workSchedule()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Invalid work schedule configuration: " + err.Error()})
+		return
+	}
+
+	now := time.Now()
+	var record models.AttendanceRecord
+	if err := config.GetDB().Where("employee_id = ? AND date = ?", actor.ID, now.Format("2006-01-02")).First(&record).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "No check-in found for today"})
+		return
+	}
+	if record.CheckOut != nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Already checked out today"})
+		return
+	}
+
+	record.CheckOut = &now
+	evaluateAttendance(&record, schedule)
+
+	if err := config.GetDB().Save(&record).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check out"})
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// GetAttendance godoc
+// @Summary Get attendance records
+// @Description List daily attendance records, optionally filtered by employee and date range
+// @Tags Attendance
+// @Produce json
+// @Param employee_id query int false "Employee ID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date (YYYY-MM-DD)"
+// @Success 200 {array} models.AttendanceRecord
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/attendance [get]
+func GetAttendance(c *gin.Context) {
+	query := config.GetDB().Model(&models.AttendanceRecord{})
+
+	if employeeID := c.Query("employee_id"); employeeID != "" {
+		id, err := strconv.Atoi(employeeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+			return
+		}
+		query = query.Where("employee_id = ?", id)
+	}
+	for param, condition := range map[string]string{"from": "date >= ?", "to": "date <= ?"} {
+		if value := c.Query(param); value != "" {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid " + param + " date, expected YYYY-MM-DD"})
+				return
+			}
+			query = query.Where(condition, value)
+		}
+	}
+
+	var records []models.AttendanceRecord
+	if err := query.Order("date, employee_id").Find(&records).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch attendance records"})
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
+}
+
+// AttendanceCorrectionRequest là dữ liệu quản lý gửi để chỉnh sửa chấm công một ngày
+type AttendanceCorrectionRequest struct {
+	EmployeeID uint       `json:"employee_id" binding:"required"`
+	Date       string     `json:"date" binding:"required"` // YYYY-MM-DD
+	CheckIn    *time.Time `json:"check_in"`
+	CheckOut   *time.Time `json:"check_out"`
+	Reason     string     `json:"reason" binding:"required"`
+}
+
+// CorrectAttendance godoc
+// @Summary Correct an attendance record
+// @Description A manager who can approve for the employee sets their check-in/check-out for a day, creating the record if missing; a reason is required and nobody can correct their own attendance
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param correction body AttendanceCorrectionRequest true "Correction data"
+// @Success 200 {object} models.AttendanceRecord
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/attendance/corrections [post]
+func CorrectAttendance(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var request AttendanceCorrectionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "employee_id, date and reason are required"})
+		return
+	}
+	// Chấm công quyết định ngày công nên không được tự sửa cho mình
+	if request.EmployeeID == actor.ID {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "You cannot correct your own attendance"})
+		return
+	}
+	if !canApproveFor(actor, request.EmployeeID) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can correct attendance"})
+		return
+	}
+	day, err := time.ParseInLocation("2006-01-02", request.Date, time.Local)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid date, expected YYYY-MM-DD"})
+		return
+	}
+	if request.CheckIn == nil && request.CheckOut != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "check_out requires check_in"})
+		return
+	}
+	if request.CheckIn != nil && request.CheckOut != nil && request.CheckOut.Before(*request.CheckIn) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "check_out must be after check_in"})
+		return
+	}
+
+	var employee models.Employee
+	if err := config.GetDB().First(&employee, request.EmployeeID).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	schedule, err := workSchedule()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Invalid work schedule configuration: " + err.Error()})
+		return
+	}
+
+	var record models.AttendanceRecord
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("employee_id = ? AND date = ?", employee.ID, request.Date).First(&record).Error; err != nil {
+			record = models.AttendanceRecord{EmployeeID: employee.ID, Date: models.CustomTime{Time: day}}
+		}
+
+		correction := models.AttendanceCorrection{
+			OldCheckIn:    record.CheckIn,
+			OldCheckOut:   record.CheckOut,
+			NewCheckIn:    request.CheckIn,
+			NewCheckOut:   request.CheckOut,
+			Reason:        request.Reason,
+			CorrectedByID: actor.ID,
+		}
+
+		record.CheckIn = request.CheckIn
+		record.CheckOut = request.CheckOut
+		record.Corrected = true
+		evaluateAttendance(&record, schedule)
+
+		// Xóa giờ vào/ra nghĩa là nhân viên vắng mặt ngày đó
+		if record.CheckIn == nil {
+			if record.ID != 0 {
+				correction.AttendanceRecordID = record.ID
+				if err := tx.Create(&correction).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&record).Error
+			}
+			return nil
+		}
+
+		if err := tx.Save(&record).Error; err != nil {
+			return err
+		}
+		correction.AttendanceRecordID = record.ID
+		return tx.Create(&correction).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to correct attendance"})
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// TimesheetDay là tình trạng chấm công của một ngày trong bảng công
+type TimesheetDay struct {
+	Date              string     `json:"date"`
+	Workday           bool       `json:"workday"`
+	Status            string     `json:"status"` // present, checked_in, holiday, paid_leave, unpaid_leave, absent, off, upcoming
+	Holiday           string     `json:"holiday,omitempty"`
+	CheckIn           *time.Time `json:"check_in,omitempty"`
+	CheckOut          *time.Time `json:"check_out,omitempty"`
+	LateMinutes       int        `json:"late_minutes"`
+	EarlyLeaveMinutes int        `json:"early_leave_minutes"`
+}
+
+// Timesheet là bảng công tháng của nhân viên, dùng để tính số ngày công
+type Timesheet struct {
+	EmployeeID        uint           `json:"employee_id"`
+	PeriodStart       string         `json:"period_start"`
+	PeriodEnd         string         `json:"period_end"`
+	ScheduledDays     int            `json:"scheduled_days"`
+	WorkingDays       float64        `json:"working_days"` // Ngày làm việc có đủ giờ vào, giờ ra cộng ngày lễ và ngày nghỉ có lương, trừ phần nghỉ không lương
+	PresentDays       int            `json:"present_days"` // Ngày làm việc theo lịch có đủ giờ vào, giờ ra
+	HolidayDays       int            `json:"holiday_days"`
+	PaidLeaveDays     float64        `json:"paid_leave_days"`
+	UnpaidLeaveDays   float64        `json:"unpaid_leave_days"`
+	AbsentDays        int            `json:"absent_days"`
+	MissingCheckOuts  int            `json:"missing_check_outs"` // Ngày làm việc đã qua chỉ có giờ vào, chưa được tính công
+	OffDayWorkDays    int            `json:"off_day_work_days"`  // Ngày nghỉ hoặc ngày lễ có đi làm, không tính vào ngày công
+	LateDays          int            `json:"late_days"`
+	LateMinutes       int            `json:"late_minutes"`
+	EarlyLeaveDays    int            `json:"early_leave_days"`
+	EarlyLeaveMinutes int            `json:"early_leave_minutes"`
+	Days              []TimesheetDay `json:"days"`
+}
+
+// buildTimesheet tổng hợp chấm công của nhân viên trong khoảng [start, end]
+func buildTimesheet(employeeID uint, start, end time.Time) (Timesheet, error) {
+	timesheet := Timesheet{
+		EmployeeID:  employeeID,
+		PeriodStart: start.Format("2006-01-02"),
+		PeriodEnd:   end.Format("2006-01-02"),
+		Days:        []TimesheetDay{},
+	}
+
+	schedule, err := workSchedule()
+	if err != nil {
+		return timesheet, err
+	}
+
+	var records []models.AttendanceRecord
+	if err := config.GetDB().
+		Where("employee_id = ? AND date BETWEEN ? AND ?", employeeID, timesheet.PeriodStart, timesheet.PeriodEnd).
+		Find(&records).Error; err != nil {
+		return timesheet, err
+	}
+	byDate := map[string]models.AttendanceRecord{}
+	for _, r := range records {
+		byDate[r.Date.Format("2006-01-02")] = r
+	}
+
+	holidays, err := holidaySet(start, end)
+	if err != nil {
+		return timesheet, err
+	}
+
+	// Đơn nghỉ phép đã duyệt giao với kỳ
+	var leaves []models.LeaveRequest
+	if err := config.GetDB().Preload("LeaveType").
+		Where("employee_id = ? AND status = ? AND start_date <= ? AND end_date >= ?",
+			employeeID, models.RequestApproved, timesheet.PeriodEnd, timesheet.PeriodStart).
+		Find(&leaves).Error; err != nil {
+		return timesheet, err
+	}
+	// So sánh theo chuỗi ngày để không phụ thuộc múi giờ của cột date
+	leaveOn := func(date string) *models.LeaveRequest {
+		for i := range leaves {
+			if date >= leaves[i].StartDate.Format("2006-01-02") && date <= leaves[i].EndDate.Format("2006-01-02") {
+				return &leaves[i]
+			}
+		}
+		return nil
+	}
+	// Đơn nghỉ nửa ngày chỉ chiếm nửa ngày công
+	leaveDays := func(leave *models.LeaveRequest) float64 {
+		if leave.HalfDay {
+			return 0.5
+		}
+		return 1
+	}
+	// Phần nghỉ không lương của những ngày có đi làm, trừ khỏi ngày công
+	unpaidPresentDays := 0.0
+
+	today := dateOnly(time.Now())
+	for day := dateOnly(start); !day.After(end); day = day.AddDate(0, 0, 1) {
+		entry := TimesheetDay{Date: day.Format("2006-01-02"), Workday: schedule.IsWorkday(day)}
+		holiday, isHoliday := holidays[entry.Date]
+		if isHoliday {
+			// Ngày lễ không phải ngày làm việc theo lịch
+			entry.Workday = false
+			entry.Holiday = holiday.Name
+		}
+		if entry.Workday {
+			timesheet.ScheduledDays++
+		}
+
+		if record, ok := byDate[entry.Date]; ok {
+			entry.Status = record.Status
+			entry.CheckIn = record.CheckIn
+			entry.CheckOut = record.CheckOut
+			entry.LateMinutes = record.LateMinutes
+			entry.EarlyLeaveMinutes = record.EarlyLeaveMinutes
+
+			switch {
+			case !entry.Workday:
+				// Đi làm ngày nghỉ, ngày lễ được báo riêng; ngày lễ vẫn được hưởng lương như không đi làm
+				if isHoliday && schedule.IsWorkday(day) {
+					timesheet.HolidayDays++
+				}
+				if record.CheckOut != nil {
+					timesheet.OffDayWorkDays++
+				}
+			case record.CheckOut == nil:
+				// Chưa có giờ ra thì chưa tính công, trừ hôm nay vẫn đang làm
+				if day.Before(today) {
+					timesheet.MissingCheckOuts++
+				}
+			default:
+				timesheet.PresentDays++
+				// Đi làm trong ngày có đơn nghỉ không lương đã duyệt (thường là nửa ngày) chỉ được tính phần đã làm
+				if leave := leaveOn(entry.Date); leave != nil && !leave.LeaveType.Paid {
+					timesheet.UnpaidLeaveDays += leaveDays(leave)
+					unpaidPresentDays += leaveDays(leave)
+				}
+			}
+			if entry.Workday && record.LateMinutes > 0 {
+				timesheet.LateDays++
+				timesheet.LateMinutes += record.LateMinutes
+			}
+			if entry.Workday && record.EarlyLeaveMinutes > 0 {
+				timesheet.EarlyLeaveDays++
+				timesheet.EarlyLeaveMinutes += record.EarlyLeaveMinutes
+			}
+		} else {
+			leave := leaveOn(entry.Date)
+			switch {
+			case isHoliday:
+				// Ngày lễ được hưởng lương dù không đi làm
+				entry.Status = "holiday"
+				if schedule.IsWorkday(day) {
+					timesheet.HolidayDays++
+				}
+			case !entry.Workday:
+				entry.Status = "off"
+			case leave != nil:
+				// Nghỉ nửa ngày mà không chấm công chỉ được tính nửa ngày nghỉ
+				days := leaveDays(leave)
+				if leave.LeaveType.Paid {
+					entry.Status = "paid_leave"
+					timesheet.PaidLeaveDays += days
+				} else {
+					entry.Status = "unpaid_leave"
+					timesheet.UnpaidLeaveDays += days
+				}
+			case day.After(today):
+				entry.Status = "upcoming"
+			default:
+				entry.Status = "absent"
+				timesheet.AbsentDays++
+			}
+		}
+
+		timesheet.Days = append(timesheet.Days, entry)
+	}
+
+	// Ngày nghỉ không lương không được tính công, kể cả phần nghỉ của ngày có đi làm
+	timesheet.WorkingDays = float64(timesheet.PresentDays+timesheet.HolidayDays) + timesheet.PaidLeaveDays - unpaidPresentDays
+
+	return timesheet, nil
+}
+
+// GetEmployeeTimesheet godoc
+// @Summary Get monthly timesheet of an employee
+// @Description Daily attendance for a pay period with working days, absences, late and early-leave totals. Only scheduled workdays with both check-in and check-out count as present; past workdays without a check-out and work on days off are reported separately.
+// @Tags Attendance
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param year query int true "Year"
+// @Param month query int true "Month"
+// @Success 200 {object} Timesheet
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/timesheet [get]
+func GetEmployeeTimesheet(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+		return
+	}
+	year, errYear := strconv.Atoi(c.Query("year"))
+	month, errMonth := strconv.Atoi(c.Query("month"))
+	if errYear != nil || errMonth != nil || month < 1 || month > 12 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Valid year and month are required"})
+		return
+	}
+
+	var employee models.Employee
+	if err := config.GetDB().First(&employee, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	start, end := models.PeriodBounds(year, month)
+	timesheet, err := buildTimesheet(employee.ID, start, end)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to build timesheet"})
+		return
+	}
+
+	c.JSON(http.StatusOK, timesheet)
+}
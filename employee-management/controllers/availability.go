@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"employee-management/calendar"
+	"employee-management/config"
+	"employee-management/models"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Chính sách xử lý khi công tác trùng lịch với công tác khác hoặc nghỉ phép
+const (
+	OverlapBlock = "block" // Từ chối lưu công tác
+	OverlapWarn  = "warn"  // Vẫn lưu nhưng trả về cảnh báo
+)
+
+// maxAvailabilityDays giới hạn độ dài khoảng thời gian khi tra cứu lịch trống
+const maxAvailabilityDays = 366
+
+// AvailabilityDay là tình trạng lịch của nhân viên trong một ngày
+type AvailabilityDay struct {
+	Date      string             `json:"date"`
+	Workday   bool               `json:"workday"`
+	Holiday   string             `json:"holiday,omitempty"`
+	Available bool               `json:"available"`
+	Busy      []ScheduleConflict `json:"busy"`
+}
+
+// Availability là lịch trống của nhân viên trong khoảng thời gian
+type Availability struct {
+	EmployeeID uint               `json:"employee_id"`
+	From       string             `json:"from"`
+	To         string             `json:"to"`
+	Available  bool               `json:"available"` // true nếu không vướng lịch nào trong cả khoảng
+	Conflicts  []ScheduleConflict `json:"conflicts"`
+	Days       []AvailabilityDay  `json:"days"`
+}
+
+// overlapPolicy đọc chính sách trùng lịch công tác từ WORK_ASSIGNMENT_OVERLAP_POLICY, mặc định là block
+func overlapPolicy() string {
+	if strings.EqualFold(config.GetEnv("WORK_ASSIGNMENT_OVERLAP_POLICY"), OverlapWarn) {
+		return OverlapWarn
+	}
+	return OverlapBlock
+}
+
+// assignmentRange trả về khoảng ngày của công tác, công tác không có ngày kết thúc chỉ tính ngày bắt đầu
+func assignmentRange(assignment models.WorkAssignment) (time.Time, time.Time) {
+	if assignment.EndDate.IsZero() {
+		return assignment.StartDate.Time, assignment.StartDate.Time
+	}
+	return assignment.StartDate.Time, assignment.EndDate.Time
+}
+
+// validateAssignmentDates kiểm tra ngày bắt đầu và kết thúc của công tác
+func validateAssignmentDates(assignment models.WorkAssignment) error {
+	if assignment.StartDate.IsZero() {
+		return fmt.Errorf("start_date is required")
+	}
+	if !assignment.EndDate.IsZero() && calendar.DateKey(assignment.EndDate.Time) < calendar.DateKey(assignment.StartDate.Time) {
+		return fmt.Errorf("end_date must not be before start_date")
+	}
+	return nil
+}
+
+// assignmentConflicts tìm các lịch trùng với công tác. Công tác khác và nghỉ phép đã duyệt được xử lý
+// theo chính sách trùng lịch; ca làm việc đã xếp chỉ là cảnh báo vì đi công tác thay cho ca làm.
+func assignmentConflicts(assignment models.WorkAssignment) ([]ScheduleConflict, []ScheduleConflict, error) {
+	from, to := assignmentRange(assignment)
+	conflicts, err := workAssignmentConflicts(assignment.EmployeeID, from, to, assignment.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	leaves, err := leaveConflicts(assignment.EmployeeID, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	shifts, err := shiftConflicts(assignment.EmployeeID, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(conflicts, leaves...), shifts, nil
+}
+
+// writeConflictWarnings thêm header Warning cho từng lịch trùng khi chính sách là cảnh báo,
+// giữ cho client cũ; lịch trùng cũng được trả trong trường warnings của phản hồi
+func writeConflictWarnings(c *gin.Context, conflicts []ScheduleConflict) {
+	for _, conflict := range conflicts {
+		c.Writer.Header().Add("Warning", fmt.Sprintf(`199 - "Overlaps %s %d (%s to %s)"`, conflict.Type, conflict.ReferenceID, conflict.From, conflict.To))
+	}
+}
+
+// GetEmployeeAvailability godoc
+// @Summary Get employee availability
+// @Description List the days an employee is free or busy with work assignments, approved leave or shifts, to check before assigning work
+// @Tags WorkAssignment
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Success 200 {object} Availability
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/availability [get]
+func GetEmployeeAvailability(c *gin.Context) {
+	var employee models.Employee
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&employee).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+	if to.Sub(from) > maxAvailabilityDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("The range must not exceed %d days", maxAvailabilityDays)})
+		return
+	}
+
+	conflicts, err := absenceConflicts(employee.ID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check schedule conflicts"})
+		return
+	}
+	shifts, err := shiftConflicts(employee.ID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check schedule conflicts"})
+		return
+	}
+	conflicts = append(conflicts, shifts...)
+
+	schedule, err := workSchedule()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Invalid work schedule configuration"})
+		return
+	}
+	holidays, err := holidaySet(from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch holidays"})
+		return
+	}
+
+	availability := Availability{
+		EmployeeID: employee.ID,
+		From:       calendar.DateKey(from),
+		To:         calendar.DateKey(to),
+		Available:  len(conflicts) == 0,
+		Conflicts:  conflicts,
+		Days:       []AvailabilityDay{},
+	}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := calendar.DateKey(day)
+		entry := AvailabilityDay{Date: key, Workday: schedule.IsWorkday(day), Busy: []ScheduleConflict{}}
+		if holiday, ok := holidays[key]; ok {
+			entry.Holiday = holiday.Name
+			entry.Workday = false
+		}
+		for _, conflict := range conflicts {
+			if conflict.From <= key && key <= conflict.To {
+				entry.Busy = append(entry.Busy, conflict)
+			}
+		}
+		entry.Available = len(entry.Busy) == 0
+		availability.Days = append(availability.Days, entry)
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
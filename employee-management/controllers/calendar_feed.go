@@ -0,0 +1,485 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"employee-management/calendar"
+	"employee-management/config"
+	"employee-management/ical"
+	"employee-management/models"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Khoảng thời gian đưa vào lịch iCalendar, tính từ ngày hiện tại
+const (
+	feedPastDays   = 90
+	feedFutureDays = 365
+)
+
+// CalendarFeedResponse là lịch iCalendar kèm đường dẫn để đăng ký
+type CalendarFeedResponse struct {
+	models.CalendarFeed
+	URL string `json:"url"`
+}
+
+// ImportSkipEntry là một sự kiện trong file lịch không được nhập thành công tác
+type ImportSkipEntry struct {
+	UID        string `json:"uid"`
+	Summary    string `json:"summary"`
+	EmployeeID uint   `json:"employee_id,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+// ImportWorkAssignmentsResult liệt kê các công tác đã tạo và sự kiện bị bỏ qua khi nhập lịch
+type ImportWorkAssignmentsResult struct {
+	Created  []WorkAssignmentResponse `json:"created"`
+	Skipped  []ImportSkipEntry        `json:"skipped"`
+	Warnings []ScheduleConflict       `json:"warnings"` // Lịch trùng khi WORK_ASSIGNMENT_OVERLAP_POLICY=warn
+}
+
+// newFeedToken tạo mã bí mật ngẫu nhiên cho lịch
+func newFeedToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// feedURL dựng đường dẫn đầy đủ của lịch từ host của request
+func feedURL(c *gin.Context, token string) string {
+	scheme := "http"
+	if c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/api/v1/calendar/feeds/%s.ics", scheme, c.Request.Host, token)
+}
+
+// createCalendarFeed lưu một lịch mới cho phạm vi và trả về đường dẫn đăng ký
+func createCalendarFeed(c *gin.Context, actor models.Employee, scope string, referenceID uint) {
+	token, err := newFeedToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to generate feed token"})
+		return
+	}
+	feed := models.CalendarFeed{Token: token, Scope: scope, ReferenceID: referenceID, CreatedByID: actor.ID}
+	if err := config.GetDB().Create(&feed).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create calendar feed"})
+		return
+	}
+	c.JSON(http.StatusCreated, CalendarFeedResponse{CalendarFeed: feed, URL: feedURL(c, feed.Token)})
+}
+
+// feedEvents tạo các sự kiện lịch của các nhân viên trong khoảng [from, to]:
+// công tác chưa hủy, nghỉ phép đã duyệt và ngày lễ
+func feedEvents(employees []models.Employee, from, to time.Time, withNames bool) ([]ical.Event, error) {
+	ids := make([]uint, 0, len(employees))
+	names := make(map[uint]string, len(employees))
+	for _, employee := range employees {
+		ids = append(ids, employee.ID)
+		names[employee.ID] = employee.Name
+	}
+	title := func(employeeID uint, summary string) string {
+		if withNames {
+			return names[employeeID] + ": " + summary
+		}
+		return summary
+	}
+
+	events := []ical.Event{}
+	if len(ids) > 0 {
+		// start_date/end_date của công tác lưu dạng timestamp, end_date trước năm 1900 nghĩa là không có ngày kết thúc
+		var assignments []models.WorkAssignment
+		if err := config.GetDB().
+			Where("employee_id IN ? AND status <> ? AND start_date < ?", ids, models.AssignmentCancelled, calendar.DateKey(to.AddDate(0, 0, 1))).
+			Where("end_date >= ? OR (end_date < ? AND start_date >= ?)", calendar.DateKey(from), "1900-01-01", calendar.DateKey(from)).
+			Order("start_date, id").
+			Find(&assignments).Error; err != nil {
+			return nil, err
+		}
+		for _, assignment := range assignments {
+			start, end := assignmentRange(assignment)
+			if end.Year() < 1900 {
+				end = start
+			}
+			status := "CONFIRMED"
+			if assignment.Status == models.AssignmentPlanned {
+				status = "TENTATIVE"
+			}
+			events = append(events, ical.Event{
+				UID:         fmt.Sprintf("workassignment-%d@employee-management", assignment.ID),
+				Summary:     title(assignment.EmployeeID, assignment.Assignment),
+				Description: "Work assignment (" + assignment.Status + ")",
+				Location:    assignment.Destination,
+				Category:    "Work assignment",
+				Status:      status,
+				Start:       start,
+				End:         end,
+			})
+		}
+
+		var leaves []models.LeaveRequest
+		if err := config.GetDB().Preload("LeaveType").
+			Where("employee_id IN ? AND status = ? AND start_date <= ? AND end_date >= ?",
+				ids, models.RequestApproved, calendar.DateKey(to), calendar.DateKey(from)).
+			Order("start_date, id").
+			Find(&leaves).Error; err != nil {
+			return nil, err
+		}
+		for _, leave := range leaves {
+			summary := leave.LeaveType.Name
+			if leave.HalfDay {
+				summary += " (half day)"
+			}
+			events = append(events, ical.Event{
+				UID:      fmt.Sprintf("leave-%d@employee-management", leave.ID),
+				Summary:  title(leave.EmployeeID, summary),
+				Category: "Leave",
+				Status:   "CONFIRMED",
+				Start:    leave.StartDate.Time,
+				End:      leave.EndDate.Time,
+			})
+		}
+	}
+
+	var holidays []models.Holiday
+	if err := config.GetDB().Where("date BETWEEN ? AND ?", calendar.DateKey(from), calendar.DateKey(to)).
+		Order("date").Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	for _, holiday := range holidays {
+		events = append(events, ical.Event{
+			UID:      fmt.Sprintf("holiday-%s@employee-management", calendar.DateKey(holiday.Date.Time)),
+			Summary:  holiday.Name,
+			Category: "Holiday",
+			Status:   "CONFIRMED",
+			Start:    holiday.Date.Time,
+			End:      holiday.Date.Time,
+		})
+	}
+	return events, nil
+}
+
+// GetCalendarFeeds godoc
+// @Summary Get my calendar feeds
+// @Description List the iCalendar feeds created by the current employee
+// @Tags Calendar
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {array} CalendarFeedResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/calendar/feeds [get]
+func GetCalendarFeeds(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var feeds []models.CalendarFeed
+	if err := config.GetDB().Where("created_by_id = ?", actor.ID).Order("id").Find(&feeds).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch calendar feeds"})
+		return
+	}
+	result := make([]CalendarFeedResponse, 0, len(feeds))
+	for _, feed := range feeds {
+		result = append(result, CalendarFeedResponse{CalendarFeed: feed, URL: feedURL(c, feed.Token)})
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CreateEmployeeCalendarFeed godoc
+// @Summary Create an employee calendar feed
+// @Description Create a tokenized iCalendar feed with an employee's work assignments, approved leave and holidays. Employees can create their own feed, managers any employee's.
+// @Tags Calendar
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 201 {object} CalendarFeedResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/calendar-feed [post]
+func CreateEmployeeCalendarFeed(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var employee models.Employee
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&employee).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+	if actor.ID != employee.ID && !hasRole(actor, managerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only the employee or a manager can create this calendar feed"})
+		return
+	}
+	createCalendarFeed(c, actor, models.FeedScopeEmployee, employee.ID)
+}
+
+// CreateDepartmentCalendarFeed godoc
+// @Summary Create a department calendar feed
+// @Description A manager creates a tokenized iCalendar feed showing who in the department is away on assignments or leave
+// @Tags Calendar
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 201 {object} CalendarFeedResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/departments/{id}/calendar-feed [post]
+func CreateDepartmentCalendarFeed(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, managerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can create department calendar feeds"})
+		return
+	}
+
+	var department models.Department
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&department).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Department not found"})
+		return
+	}
+	createCalendarFeed(c, actor, models.FeedScopeDepartment, department.ID)
+}
+
+// DeleteCalendarFeed godoc
+// @Summary Revoke a calendar feed
+// @Description Revoke a feed token so its URL stops working; only its creator or an HR manager can revoke it
+// @Tags Calendar
+// @Produce json
+// @Param token path string true "Feed token"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {object} ResponseMessage
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/calendar/feeds/{token} [delete]
+func DeleteCalendarFeed(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var feed models.CalendarFeed
+	if err := config.GetDB().Where("token = ?", strings.TrimSuffix(c.Param("token"), ".ics")).First(&feed).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Calendar feed not found"})
+		return
+	}
+	if feed.CreatedByID != actor.ID && !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only the creator or an HR manager can revoke this calendar feed"})
+		return
+	}
+	if err := config.GetDB().Delete(&feed).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to revoke calendar feed"})
+		return
+	}
+	c.JSON(http.StatusOK, ResponseMessage{Message: "Calendar feed revoked successfully"})
+}
+
+// GetCalendarFeedICS godoc
+// @Summary Download a calendar feed
+// @Description Public iCalendar (RFC 5545) feed identified by its token, covering the last 90 and next 365 days
+// @Tags Calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token, optionally followed by .ics"
+// @Success 200 {string} string
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/calendar/feeds/{token} [get]
+func GetCalendarFeedICS(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	var feed models.CalendarFeed
+	if token == "" || config.GetDB().Where("token = ?", token).First(&feed).Error != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Calendar feed not found"})
+		return
+	}
+
+	var employees []models.Employee
+	var name string
+	switch feed.Scope {
+	case models.FeedScopeEmployee:
+		if err := config.GetDB().Where("id = ?", feed.ReferenceID).Find(&employees).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch employee"})
+			return
+		}
+		if len(employees) == 0 {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Calendar feed not found"})
+			return
+		}
+		name = employees[0].Name
+	case models.FeedScopeDepartment:
+		var department models.Department
+		if err := config.GetDB().Where("id = ?", feed.ReferenceID).First(&department).Error; err != nil {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Calendar feed not found"})
+			return
+		}
+		if err := config.GetDB().
+			Where("id IN (?)", activeOn(config.GetDB().Table("employee_departments").Select("employee_id").Where("department_id = ?", department.ID), "employee_departments", time.Now())).
+			Find(&employees).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch department employees"})
+			return
+		}
+		name = department.Name
+	default:
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Calendar feed not found"})
+		return
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	events, err := feedEvents(employees, today.AddDate(0, 0, -feedPastDays), today.AddDate(0, 0, feedFutureDays), feed.Scope == models.FeedScopeDepartment)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to build calendar feed"})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, ical.Calendar{Name: name, Events: events}, now); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to build calendar feed"})
+		return
+	}
+	config.GetDB().Model(&feed).Update("last_accessed_at", now)
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s-%d.ics"`, feed.Scope, feed.ReferenceID))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
+
+// ImportWorkAssignments godoc
+// @Summary Import work assignments from an iCalendar file
+// @Description A manager bulk-creates planned work assignments from the VEVENTs of an .ics file. Events are assigned to employee_id when given, otherwise to the employees whose email is an ATTENDEE. Dates and schedule overlaps are checked for each event; overlapping shifts are only reported as warnings.
+// @Tags WorkAssignment
+// @Accept multipart/form-data
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param file formData file true "iCalendar file"
+// @Param employee_id formData int false "Assign all events to this employee"
+// @Success 201 {object} ImportWorkAssignmentsResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workassignments/import [post]
+func ImportWorkAssignments(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, managerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can import work assignments"})
+		return
+	}
+
+	var fixed *models.Employee
+	if value := c.PostForm("employee_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+			return
+		}
+		var employee models.Employee
+		if err := config.GetDB().First(&employee, id).Error; err != nil {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+			return
+		}
+		fixed = &employee
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Calendar file is required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Failed to read calendar file"})
+		return
+	}
+	defer file.Close()
+
+	events, err := ical.Parse(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Invalid calendar file: %v", err)})
+		return
+	}
+
+	result := ImportWorkAssignmentsResult{Created: []WorkAssignmentResponse{}, Skipped: []ImportSkipEntry{}, Warnings: []ScheduleConflict{}}
+	for _, event := range events {
+		skip := func(employeeID uint, reason string) {
+			result.Skipped = append(result.Skipped, ImportSkipEntry{UID: event.UID, Summary: event.Summary, EmployeeID: employeeID, Reason: reason})
+		}
+		if event.Status == "CANCELLED" {
+			skip(0, "Event is cancelled")
+			continue
+		}
+		if strings.TrimSpace(event.Summary) == "" {
+			skip(0, "Event has no summary")
+			continue
+		}
+
+		// Xác định nhân viên được giao: employee_id cố định hoặc theo email người tham gia
+		var assignees []models.Employee
+		if fixed != nil {
+			assignees = []models.Employee{*fixed}
+		} else if len(event.Attendees) > 0 {
+			if err := config.GetDB().Where("LOWER(email) IN ?", event.Attendees).Order("id").Find(&assignees).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to match attendees"})
+				return
+			}
+		}
+		if len(assignees) == 0 {
+			skip(0, "No employee matches the event attendees")
+			continue
+		}
+
+		for _, employee := range assignees {
+			assignment := models.WorkAssignment{
+				EmployeeID:   employee.ID,
+				EmployeeName: employee.Name,
+				Assignment:   strings.TrimSpace(event.Summary),
+				Destination:  event.Location,
+				StartDate:    models.CustomTime{Time: event.Start},
+				EndDate:      models.CustomTime{Time: event.End},
+				Status:       models.AssignmentPlanned,
+			}
+			if err := validateAssignmentDates(assignment); err != nil {
+				skip(employee.ID, err.Error())
+				continue
+			}
+			conflicts, shifts, err := assignmentConflicts(assignment)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check schedule conflicts"})
+				return
+			}
+			if len(conflicts) > 0 && overlapPolicy() == OverlapBlock {
+				skip(employee.ID, "Overlaps existing schedules")
+				continue
+			}
+			if err := config.GetDB().Create(&assignment).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create work assignment"})
+				return
+			}
+			result.Warnings = append(append(result.Warnings, conflicts...), shifts...)
+			result.Created = append(result.Created, newWorkAssignmentResponse(assignment, employee))
+		}
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"employee-management/payroll"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// validCompensationReasons liệt kê các lý do điều chỉnh lương hợp lệ
+var validCompensationReasons = map[string]bool{
+	models.CompensationHire:         true,
+	models.CompensationPromotion:    true,
+	models.CompensationAnnualReview: true,
+	models.CompensationAdjustment:   true,
+}
+
+// CompensationHistory là lịch sử lương của một nhân viên kèm mức lương hiện hành
+type CompensationHistory struct {
+	EmployeeID        uint                          `json:"employee_id"`
+	CurrentBaseSalary int                           `json:"current_base_salary"`
+	Revisions         []models.CompensationRevision `json:"revisions"`
+}
+
+// approvedRates lấy các mức lương đã được duyệt của nhân viên có hiệu lực trước ngày until
+func approvedRates(employeeID uint, until time.Time) ([]payroll.Rate, error) {
+	var revisions []models.CompensationRevision
+	if err := config.GetDB().
+		Where("employee_id = ? AND status = ? AND effective_date <= ?", employeeID, models.RequestApproved, until).
+		Order("effective_date, id").
+		Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+
+	rates := make([]payroll.Rate, 0, len(revisions))
+	for _, r := range revisions {
+		rates = append(rates, payroll.Rate{Amount: r.BaseSalary, EffectiveFrom: r.EffectiveDate.Time})
+	}
+	return rates, nil
+}
+
+// GetEmployeeCompensation godoc
+// @Summary Get compensation history of an employee
+// @Description List every base salary revision of an employee and the rate effective today
+// @Tags Compensation
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 200 {object} CompensationHistory
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/compensation [get]
+func GetEmployeeCompensation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+		return
+	}
+
+	var employee models.Employee
+	if err := config.GetDB().First(&employee, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	history := CompensationHistory{EmployeeID: employee.ID}
+	if err := config.GetDB().Where("employee_id = ?", employee.ID).
+		Order("effective_date, id").Find(&history.Revisions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch compensation history"})
+		return
+	}
+
+	// Mức lương hiện hành là mức đã duyệt có hiệu lực gần nhất tính đến hôm nay
+	today := time.Now()
+	for _, r := range history.Revisions {
+		if r.Status == models.RequestApproved && !r.EffectiveDate.After(today) {
+			history.CurrentBaseSalary = r.BaseSalary
+		}
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// CreateCompensationRevision godoc
+// @Summary Request a base salary revision
+// @Description Record a new base salary with effective date and reason, pending approval
+// @Tags Compensation
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param revision body models.CompensationRevision true "Revision data"
+// @Success 201 {object} models.CompensationRevision
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/compensation [post]
+func CreateCompensationRevision(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+		return
+	}
+
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, append(payrollRoles, hrManagerRoles...)...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only payroll or HR can request compensation changes"})
+		return
+	}
+
+	var employee models.Employee
+	if err := config.GetDB().First(&employee, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	var revision models.CompensationRevision
+	if err := c.ShouldBindJSON(&revision); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	if revision.BaseSalary <= 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "base_salary must be positive"})
+		return
+	}
+	if revision.EffectiveDate.IsZero() {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "effective_date is required"})
+		return
+	}
+	if !validCompensationReasons[revision.Reason] {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid reason. Must be hire, promotion, annual_review or adjustment"})
+		return
+	}
+
+	revision.ID = 0
+	revision.EmployeeID = employee.ID
+	revision.Status = models.RequestPending
+	revision.RequestedByID = actor.ID
+	revision.ReviewedByID = nil
+	revision.ReviewedAt = nil
+	revision.ReviewComment = ""
+
+	if err := config.GetDB().Create(&revision).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create compensation revision"})
+		return
+	}
+
+	// Cảnh báo khi mức lương mới nằm ngoài khung lương của chức vụ
+	writeBandWarnings(c, employee.ID, revision.BaseSalary)
+	c.JSON(http.StatusCreated, revision)
+}
+
+// reviewCompensationRevision duyệt hoặc từ chối một yêu cầu điều chỉnh lương
+func reviewCompensationRevision(c *gin.Context, status string) {
+	employeeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+		return
+	}
+	revisionID, err := strconv.Atoi(c.Param("revision_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid revision ID"})
+		return
+	}
+
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can review compensation changes"})
+		return
+	}
+
+	var request SalaryApprovalRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	var revision models.CompensationRevision
+	if err := config.GetDB().Where("id = ? AND employee_id = ?", revisionID, employeeID).First(&revision).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Compensation revision not found"})
+		return
+	}
+	if revision.Status != models.RequestPending {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Compensation revision has already been reviewed"})
+		return
+	}
+	if revision.RequestedByID == actor.ID {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Requesters cannot review their own compensation changes"})
+		return
+	}
+
+	now := time.Now()
+	revision.Status = status
+	revision.ReviewedByID = &actor.ID
+	revision.ReviewedAt = &now
+	revision.ReviewComment = request.Comment
+	if err := config.GetDB().Save(&revision).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update compensation revision"})
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+// ApproveCompensationRevision godoc
+// @Summary Approve a base salary revision
+// @Description HR manager approves a pending compensation revision
+// @Tags Compensation
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param revision_id path int true "Revision ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Comment"
+// @Success 200 {object} models.CompensationRevision
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/compensation/{revision_id}/approve [post]
+func ApproveCompensationRevision(c *gin.Context) {
+	reviewCompensationRevision(c, models.RequestApproved)
+}
+
+// RejectCompensationRevision godoc
+// @Summary Reject a base salary revision
+// @Description HR manager rejects a pending compensation revision
+// @Tags Compensation
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param revision_id path int true "Revision ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Comment"
+// @Success 200 {object} models.CompensationRevision
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/compensation/{revision_id}/reject [post]
+func RejectCompensationRevision(c *gin.Context) {
+	reviewCompensationRevision(c, models.RequestRejected)
+}
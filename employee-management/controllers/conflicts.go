@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"employee-management/calendar"
+	"employee-management/config"
+	"employee-management/models"
+	"fmt"
+	"time"
+)
+
+// ScheduleConflict mô tả một lịch trùng với khoảng thời gian cần xếp cho nhân viên
+type ScheduleConflict struct {
+	EmployeeID  uint   `json:"employee_id"`
+	Type        string `json:"type"` // leave, work_assignment, shift
+	ReferenceID uint   `json:"reference_id"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Description string `json:"description"`
+}
+
+// leaveConflicts tìm các đơn nghỉ phép đã duyệt giao với khoảng [from, to]
+func leaveConflicts(employeeID uint, from, to time.Time) ([]ScheduleConflict, error) {
+	var leaves []models.LeaveRequest
+	if err := config.GetDB().Preload("LeaveType").
+		Where("employee_id = ? AND status = ? AND start_date <= ? AND end_date >= ?",
+			employeeID, models.RequestApproved, calendar.DateKey(to), calendar.DateKey(from)).
+		Find(&leaves).Error; err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]ScheduleConflict, 0, len(leaves))
+	for _, leave := range leaves {
+		conflicts = append(conflicts, ScheduleConflict{
+			EmployeeID:  employeeID,
+			Type:        "leave",
+			ReferenceID: leave.ID,
+			From:        calendar.DateKey(leave.StartDate.Time),
+			To:          calendar.DateKey(leave.EndDate.Time),
+			Description: leave.LeaveType.Name,
+		})
+	}
+	return conflicts, nil
+}
+
+// workAssignmentConflicts tìm các chuyến công tác giao với khoảng [from, to].
+// Công tác không có ngày kết thúc được coi là chỉ diễn ra trong ngày bắt đầu.
+func workAssignmentConflicts(employeeID uint, from, to time.Time, excludeID uint) ([]ScheduleConflict, error) {
+	// start_date/end_date lưu dạng timestamp nên so sánh với đầu ngày kế tiếp
+	dayAfter := calendar.DateKey(to.AddDate(0, 0, 1))
+	var assignments []models.WorkAssignment
+	if err := config.GetDB().
+		Where("employee_id = ? AND id <> ? AND status <> ? AND start_date < ?", employeeID, excludeID, models.AssignmentCancelled, dayAfter).
+		Where("end_date >= ? OR (end_date < ? AND start_date >= ?)", calendar.DateKey(from), "1900-01-01", calendar.DateKey(from)).
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]ScheduleConflict, 0, len(assignments))
+	for _, a := range assignments {
+		end := a.EndDate.Time
+		if end.Year() < 1900 {
+			end = a.StartDate.Time
+		}
+		conflicts = append(conflicts, ScheduleConflict{
+			EmployeeID:  employeeID,
+			Type:        "work_assignment",
+			ReferenceID: a.ID,
+			From:        calendar.DateKey(a.StartDate.Time),
+			To:          calendar.DateKey(end),
+			Description: a.Assignment,
+		})
+	}
+	return conflicts, nil
+}
+
+// shiftConflicts tìm các ca đã xếp cho nhân viên trong khoảng [from, to]
+func shiftConflicts(employeeID uint, from, to time.Time, excludeIDs ...uint) ([]ScheduleConflict, error) {
+	query := config.GetDB().Preload("ShiftTemplate").
+		Where("employee_id = ? AND date BETWEEN ? AND ?", employeeID, calendar.DateKey(from), calendar.DateKey(to))
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
+	}
+
+	var shifts []models.ShiftAssignment
+	if err := query.Find(&shifts).Error; err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]ScheduleConflict, 0, len(shifts))
+	for _, s := range shifts {
+		date := calendar.DateKey(s.Date.Time)
+		conflicts = append(conflicts, ScheduleConflict{
+			EmployeeID:  employeeID,
+			Type:        "shift",
+			ReferenceID: s.ID,
+			From:        date,
+			To:          date,
+			Description: fmt.Sprintf("%s (%s-%s)", s.ShiftTemplate.Name, s.ShiftTemplate.StartTime, s.ShiftTemplate.EndTime),
+		})
+	}
+	return conflicts, nil
+}
+
+// absenceConflicts gộp các xung đột với nghỉ phép và công tác
+func absenceConflicts(employeeID uint, from, to time.Time) ([]ScheduleConflict, error) {
+	conflicts, err := leaveConflicts(employeeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	trips, err := workAssignmentConflicts(employeeID, from, to, 0)
+	if err != nil {
+		return nil, err
+	}
+	return append(conflicts, trips...), nil
+}
@@ -0,0 +1,655 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Giới hạn thời hạn hợp đồng theo Bộ luật Lao động 2019
+const (
+	maxProbationDays        = 180 // Thời gian thử việc tối đa (Điều 25)
+	maxFixedTermMonths      = 36  // Thời hạn tối đa của hợp đồng xác định thời hạn (Điều 20)
+	maxConsecutiveFixedTerm = 2   // Số hợp đồng xác định thời hạn liên tiếp tối đa (Điều 20)
+)
+
+// errContractChanged báo hợp đồng đã bị gia hạn hoặc chấm dứt bởi một yêu cầu khác
+var errContractChanged = errors.New("contract status changed concurrently")
+
+// ContractTerminationRequest là lý do chấm dứt hợp đồng trước hạn
+type ContractTerminationRequest struct {
+	Note string `json:"note"`
+}
+
+// ExpiringContract là hợp đồng sắp hết hạn kèm số ngày còn lại
+type ExpiringContract struct {
+	models.EmploymentContract
+	EmployeeName string `json:"employee_name"`
+	DaysLeft     int    `json:"days_left"`
+}
+
+// validContractType kiểm tra loại hợp đồng
+func validContractType(contractType string) bool {
+	switch contractType {
+	case models.ContractProbation, models.ContractFixedTerm, models.ContractIndefinite:
+		return true
+	}
+	return false
+}
+
+// daysUntil trả về số ngày từ ngày from tới ngày to, âm nếu to đã qua
+func daysUntil(from, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// validateContract kiểm tra loại, mức lương và thời hạn của hợp đồng
+func validateContract(contract models.EmploymentContract) error {
+	if !validContractType(contract.Type) {
+		return fmt.Errorf("Invalid type. Must be probation, fixed_term or indefinite")
+	}
+	if contract.BaseSalary <= 0 {
+		return fmt.Errorf("base_salary must be positive")
+	}
+	if contract.StartDate.IsZero() {
+		return fmt.Errorf("start_date is required")
+	}
+	if contract.Type == models.ContractIndefinite {
+		if !contract.EndDate.IsZero() {
+			return fmt.Errorf("An indefinite contract must not have an end_date")
+		}
+		return nil
+	}
+	if contract.EndDate.IsZero() {
+		return fmt.Errorf("end_date is required for %s contracts", contract.Type)
+	}
+	if contract.EndDate.Before(contract.StartDate.Time) {
+		return fmt.Errorf("end_date must not be before start_date")
+	}
+	if contract.Type == models.ContractProbation && daysUntil(contract.StartDate.Time, contract.EndDate.Time) >= maxProbationDays {
+		return fmt.Errorf("A probation contract must not exceed %d days", maxProbationDays)
+	}
+	if contract.Type == models.ContractFixedTerm && contract.EndDate.After(contract.StartDate.AddDate(0, maxFixedTermMonths, 0)) {
+		return fmt.Errorf("A fixed-term contract must not exceed %d months", maxFixedTermMonths)
+	}
+	return nil
+}
+
+// parseFormDate đọc ngày YYYY-MM-DD từ form, trả về thời gian rỗng nếu không có
+func parseFormDate(c *gin.Context, field string) (models.CustomTime, error) {
+	value := strings.TrimSpace(c.PostForm(field))
+	if value == "" {
+		return models.CustomTime{}, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return models.CustomTime{}, fmt.Errorf("Invalid %s format. Use YYYY-MM-DD", field)
+	}
+	return models.CustomTime{Time: day}, nil
+}
+
+// bindContractForm đọc các trường hợp đồng từ form vào contract, trường bỏ trống giữ giá trị sẵn có
+func bindContractForm(c *gin.Context, contract *models.EmploymentContract) error {
+	if value := strings.TrimSpace(c.PostForm("type")); value != "" {
+		contract.Type = value
+	}
+	if value := strings.TrimSpace(c.PostForm("base_salary")); value != "" {
+		salary, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("base_salary must be a number")
+		}
+		contract.BaseSalary = salary
+	}
+	for field, date := range map[string]*models.CustomTime{"start_date": &contract.StartDate, "end_date": &contract.EndDate} {
+		value, err := parseFormDate(c, field)
+		if err != nil {
+			return err
+		}
+		if !value.IsZero() {
+			*date = value
+		}
+	}
+	if contract.Type == models.ContractIndefinite {
+		contract.EndDate = models.CustomTime{}
+	}
+	if value, ok := c.GetPostForm("contract_number"); ok {
+		contract.ContractNumber = strings.TrimSpace(value)
+	}
+	contract.Note = c.PostForm("note")
+	return nil
+}
+
+// saveContractDocument lưu bản hợp đồng đã ký nếu có trong form thành giấy tờ loại contract của nhân viên.
+// Nếu không hợp lệ, hàm tự trả lỗi về client và trả về false.
+func saveContractDocument(c *gin.Context, actor models.Employee, contract *models.EmploymentContract) (*models.EmployeeDocument, bool) {
+	fileHeader, err := c.FormFile("document")
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, true
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid document upload"})
+		return nil, false
+	}
+	document := models.EmployeeDocument{
+		EmployeeID:   contract.EmployeeID,
+		Category:     models.DocumentContract,
+		Description:  strings.TrimSpace("Signed contract " + contract.ContractNumber),
+		UploadedByID: actor.ID,
+	}
+	if !storeDocument(c, fileHeader, &document) {
+		return nil, false
+	}
+	contract.DocumentID = &document.ID
+	return &document, true
+}
+
+// consecutiveFixedTerms đếm số hợp đồng xác định thời hạn liên tiếp ngay trước hợp đồng bắt đầu ngày start,
+// bỏ qua hợp đồng thử việc. Chuỗi dừng ở hợp đồng không xác định thời hạn, hợp đồng đã chấm dứt
+// hoặc khi có khoảng trống giữa ngày kết thúc của hợp đồng và ngày bắt đầu của hợp đồng kế tiếp.
+func consecutiveFixedTerms(tx *gorm.DB, employeeID uint, start time.Time) (int, error) {
+	var contracts []models.EmploymentContract
+	if err := tx.Where("employee_id = ? AND start_date < ?", employeeID, start.Format("2006-01-02")).
+		Order("start_date DESC, id DESC").Find(&contracts).Error; err != nil {
+		return 0, err
+	}
+	count, next := 0, start
+	for _, contract := range contracts {
+		if contract.Type == models.ContractIndefinite || contract.Status == models.ContractTerminated || contract.EndDate.IsZero() {
+			break
+		}
+		// So sánh theo chuỗi ngày để không phụ thuộc múi giờ của cột date
+		if contract.EndDate.AddDate(0, 0, 1).Format("2006-01-02") < next.Format("2006-01-02") {
+			break
+		}
+		if contract.Type == models.ContractFixedTerm {
+			count++
+		}
+		next = contract.StartDate.Time
+	}
+	return count, nil
+}
+
+// writeFixedTermWarning thêm header Warning khi hợp đồng xác định thời hạn mới vượt số lần ký liên tiếp luật cho phép,
+// previous là số hợp đồng xác định thời hạn liên tiếp đã ký trước đó
+func writeFixedTermWarning(c *gin.Context, previous int) {
+	if previous < maxConsecutiveFixedTerm {
+		return
+	}
+	c.Writer.Header().Add("Warning", fmt.Sprintf(`199 - "This is fixed-term contract number %d in a row; the Labor Code allows at most %d consecutive fixed-term contracts, this one should be indefinite"`,
+		previous+1, maxConsecutiveFixedTerm))
+}
+
+// findContract lấy hợp đồng theo tham số id.
+// Nếu không tìm thấy, hàm tự trả lỗi về client và trả về false.
+func findContract(c *gin.Context) (models.EmploymentContract, bool) {
+	var contract models.EmploymentContract
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&contract).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Contract not found"})
+		return contract, false
+	}
+	return contract, true
+}
+
+// requireHRManager kiểm tra người thực hiện là HR manager.
+// Nếu không phải, hàm tự trả lỗi về client và trả về false.
+func requireHRManager(c *gin.Context, message string) (models.Employee, bool) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return actor, false
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: message})
+		return actor, false
+	}
+	return actor, true
+}
+
+// GetEmployeeContracts godoc
+// @Summary Get an employee's contracts
+// @Description The employee or an HR manager gets every employment contract of the employee, oldest first, including renewed, expired and terminated ones
+// @Tags Contract
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {array} models.EmploymentContract
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/contracts [get]
+func GetEmployeeContracts(c *gin.Context) {
+	employee, _, ok := findDocumentOwner(c)
+	if !ok {
+		return
+	}
+
+	var contracts []models.EmploymentContract
+	if err := config.GetDB().Where("employee_id = ?", employee.ID).Order("start_date, id").Find(&contracts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch contracts"})
+		return
+	}
+	c.JSON(http.StatusOK, contracts)
+}
+
+// CreateEmployeeContract godoc
+// @Summary Create an employment contract
+// @Description An HR manager records a new contract for an employee without an active one. A Warning header is returned when it is the third fixed-term contract in a row or the salary is outside the pay grade band.
+// @Tags Contract
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param type formData string true "Contract type (probation/fixed_term/indefinite)"
+// @Param start_date formData string true "Start date (YYYY-MM-DD)"
+// @Param end_date formData string false "Last day (YYYY-MM-DD), required unless indefinite"
+// @Param base_salary formData int true "Base salary"
+// @Param contract_number formData string false "Paper contract number"
+// @Param note formData string false "Note"
+// @Param document formData file false "Signed contract (PDF, JPEG, PNG or WebP)"
+// @Success 201 {object} models.EmploymentContract
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/contracts [post]
+func CreateEmployeeContract(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+		return
+	}
+	actor, ok := requireHRManager(c, "Only HR managers can manage contracts")
+	if !ok {
+		return
+	}
+	var employee models.Employee
+	if err := config.GetDB().First(&employee, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	contract := models.EmploymentContract{EmployeeID: employee.ID, Status: models.ContractActive, CreatedByID: actor.ID}
+	if err := bindContractForm(c, &contract); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := validateContract(contract); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var active int64
+	if err := config.GetDB().Model(&models.EmploymentContract{}).
+		Where("employee_id = ? AND status = ?", employee.ID, models.ContractActive).Count(&active).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check active contracts"})
+		return
+	}
+	if active > 0 {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Employee already has an active contract; renew or terminate it first"})
+		return
+	}
+	previous, err := consecutiveFixedTerms(config.GetDB(), employee.ID, contract.StartDate.Time)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch contract history"})
+		return
+	}
+
+	document, ok := saveContractDocument(c, actor, &contract)
+	if !ok {
+		return
+	}
+	if err := config.GetDB().Create(&contract).Error; err != nil {
+		if document != nil {
+			discardDocument(c.Request.Context(), *document)
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create contract"})
+		return
+	}
+
+	if contract.Type == models.ContractFixedTerm {
+		writeFixedTermWarning(c, previous)
+	}
+	writeBandWarnings(c, employee.ID, contract.BaseSalary)
+	c.JSON(http.StatusCreated, contract)
+}
+
+// RenewContract godoc
+// @Summary Renew an employment contract
+// @Description An HR manager replaces an active contract with a new one. Omitted fields are copied from the current contract and the start date defaults to the day after it ends. A Warning header is returned when the renewal is the third fixed-term contract in a row or the salary is outside the pay grade band.
+// @Tags Contract
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param type formData string false "Contract type (probation/fixed_term/indefinite)"
+// @Param start_date formData string false "Start date (YYYY-MM-DD)"
+// @Param end_date formData string false "Last day (YYYY-MM-DD), required unless indefinite"
+// @Param base_salary formData int false "Base salary"
+// @Param contract_number formData string false "Paper contract number"
+// @Param note formData string false "Note"
+// @Param document formData file false "Signed contract (PDF, JPEG, PNG or WebP)"
+// @Success 201 {object} models.EmploymentContract
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/contracts/{id}/renew [post]
+func RenewContract(c *gin.Context) {
+	actor, ok := requireHRManager(c, "Only HR managers can manage contracts")
+	if !ok {
+		return
+	}
+	current, ok := findContract(c)
+	if !ok {
+		return
+	}
+	if current.Status != models.ContractActive && current.Status != models.ContractExpired {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: fmt.Sprintf("Contract is already %s", current.Status)})
+		return
+	}
+
+	renewal := models.EmploymentContract{
+		EmployeeID:  current.EmployeeID,
+		Type:        current.Type,
+		BaseSalary:  current.BaseSalary,
+		Status:      models.ContractActive,
+		RenewalOfID: &current.ID,
+		CreatedByID: actor.ID,
+	}
+	if !current.EndDate.IsZero() {
+		renewal.StartDate = models.CustomTime{Time: current.EndDate.AddDate(0, 0, 1)}
+	}
+	if err := bindContractForm(c, &renewal); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := validateContract(renewal); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !renewal.StartDate.After(current.StartDate.Time) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "start_date must be after the current contract started"})
+		return
+	}
+	previous, err := consecutiveFixedTerms(config.GetDB(), current.EmployeeID, renewal.StartDate.Time)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch contract history"})
+		return
+	}
+
+	document, ok := saveContractDocument(c, actor, &renewal)
+	if !ok {
+		return
+	}
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.EmploymentContract{}).
+			Where("id = ? AND status = ?", current.ID, current.Status).
+			Update("status", models.ContractRenewed)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errContractChanged
+		}
+		return tx.Create(&renewal).Error
+	})
+	if err != nil {
+		if document != nil {
+			discardDocument(c.Request.Context(), *document)
+		}
+		if errors.Is(err, errContractChanged) {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to renew contract"})
+		return
+	}
+
+	if renewal.Type == models.ContractFixedTerm {
+		writeFixedTermWarning(c, previous)
+	}
+	writeBandWarnings(c, renewal.EmployeeID, renewal.BaseSalary)
+	c.JSON(http.StatusCreated, renewal)
+}
+
+// TerminateContract godoc
+// @Summary Terminate an employment contract
+// @Description An HR manager ends an active contract early
+// @Tags Contract
+// @Accept json
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body ContractTerminationRequest false "Reason"
+// @Success 200 {object} models.EmploymentContract
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/contracts/{id}/terminate [post]
+func TerminateContract(c *gin.Context) {
+	if _, ok := requireHRManager(c, "Only HR managers can manage contracts"); !ok {
+		return
+	}
+	contract, ok := findContract(c)
+	if !ok {
+		return
+	}
+	if contract.Status != models.ContractActive {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: fmt.Sprintf("Contract is already %s", contract.Status)})
+		return
+	}
+
+	var request ContractTerminationRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{"status": models.ContractTerminated, "terminated_at": now}
+	if request.Note != "" {
+		updates["note"] = request.Note
+		contract.Note = request.Note
+	}
+	result := config.GetDB().Model(&models.EmploymentContract{}).
+		Where("id = ? AND status = ?", contract.ID, models.ContractActive).Updates(updates)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to terminate contract"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: errContractChanged.Error()})
+		return
+	}
+	contract.Status = models.ContractTerminated
+	contract.TerminatedAt = &now
+	c.JSON(http.StatusOK, contract)
+}
+
+// GetContractDocument godoc
+// @Summary Download a signed contract
+// @Description The employee or an HR manager downloads the signed document attached to an employment contract
+// @Tags Contract
+// @Produce octet-stream
+// @Param id path int true "Contract ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {file} file
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/contracts/{id}/document [get]
+func GetContractDocument(c *gin.Context) {
+	contract, ok := findContract(c)
+	if !ok {
+		return
+	}
+	if _, ok := ownerOrHRManager(c, contract.EmployeeID); !ok {
+		return
+	}
+	var document models.EmployeeDocument
+	if contract.DocumentID == nil || config.GetDB().First(&document, *contract.DocumentID).Error != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Contract has no signed document"})
+		return
+	}
+	serveDocument(c, document)
+}
+
+// expiringContracts tải các hợp đồng đang hiệu lực hết hạn trong vòng days ngày tới, hết hạn sớm nhất trước
+func expiringContracts(today time.Time, days int) ([]ExpiringContract, error) {
+	var contracts []models.EmploymentContract
+	// Hợp đồng không xác định thời hạn lưu end_date rỗng nên bị loại bởi mốc 1900-01-01
+	if err := config.GetDB().
+		Where("status = ? AND end_date >= ? AND end_date <= ?", models.ContractActive,
+			today.Format("2006-01-02"), today.AddDate(0, 0, days).Format("2006-01-02")).
+		Order("end_date, id").Find(&contracts).Error; err != nil {
+		return nil, err
+	}
+
+	employeeIDs := make([]uint, 0, len(contracts))
+	for _, contract := range contracts {
+		employeeIDs = append(employeeIDs, contract.EmployeeID)
+	}
+	var employees []models.Employee
+	if err := config.GetDB().Where("id IN ?", employeeIDs).Find(&employees).Error; err != nil {
+		return nil, err
+	}
+	names := map[uint]string{}
+	for _, employee := range employees {
+		names[employee.ID] = employee.Name
+	}
+
+	result := make([]ExpiringContract, 0, len(contracts))
+	for _, contract := range contracts {
+		result = append(result, ExpiringContract{
+			EmploymentContract: contract,
+			EmployeeName:       names[contract.EmployeeID],
+			DaysLeft:           daysUntil(today, contract.EndDate.Time),
+		})
+	}
+	return result, nil
+}
+
+// GetExpiringContracts godoc
+// @Summary List contracts about to expire
+// @Description HR managers list active contracts ending within the given number of days
+// @Tags Contract
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param days query int false "Look-ahead window in days" default(30)
+// @Success 200 {array} ExpiringContract
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/contracts/expiring [get]
+func GetExpiringContracts(c *gin.Context) {
+	if _, ok := requireHRManager(c, "Only HR managers can view expiring contracts"); !ok {
+		return
+	}
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(models.ContractExpiryAlertDays[0])))
+	if err != nil || days < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "days must be a non-negative integer"})
+		return
+	}
+	contracts, err := expiringContracts(time.Now(), days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch expiring contracts"})
+		return
+	}
+	c.JSON(http.StatusOK, contracts)
+}
+
+// expiryAlertThreshold trả về mốc cảnh báo nhỏ nhất đã đến với số ngày còn lại, 0 nếu chưa đến mốc nào
+func expiryAlertThreshold(daysLeft int) int {
+	threshold := 0
+	for _, days := range models.ContractExpiryAlertDays {
+		if daysLeft <= days && (threshold == 0 || days < threshold) {
+			threshold = days
+		}
+	}
+	return threshold
+}
+
+// notifyContractExpiry báo HR hợp đồng sắp hết hạn qua HR_WEBHOOK_URL nếu được cấu hình
+func notifyContractExpiry(contract ExpiringContract, threshold int) {
+	log.Printf("Contract %d of employee %d (%s) expires in %d days", contract.ID, contract.EmployeeID, contract.EmployeeName, contract.DaysLeft)
+	url := config.GetEnv("HR_WEBHOOK_URL")
+	if url == "" {
+		return
+	}
+	payload, err := json.Marshal(gin.H{
+		"event":       "contract.expiring",
+		"days_before": threshold,
+		"contract":    contract,
+	})
+	if err != nil {
+		log.Printf("Failed to encode contract %d for HR: %v", contract.ID, err)
+		return
+	}
+	postWebhook(url, payload, fmt.Sprintf("contract %d", contract.ID))
+}
+
+// FlagExpiringContracts chuyển hợp đồng đã qua ngày kết thúc sang expired và báo HR các hợp đồng
+// đến mốc 30/15/7 ngày trước khi hết hạn. Mỗi mốc của một hợp đồng chỉ được báo một lần.
+func FlagExpiringContracts() error {
+	today := time.Now()
+	if err := config.GetDB().Model(&models.EmploymentContract{}).
+		Where("status = ? AND end_date < ? AND end_date >= ?", models.ContractActive, today.Format("2006-01-02"), "1900-01-01").
+		Update("status", models.ContractExpired).Error; err != nil {
+		return err
+	}
+
+	contracts, err := expiringContracts(today, models.ContractExpiryAlertDays[0])
+	if err != nil {
+		return err
+	}
+	for _, contract := range contracts {
+		threshold := expiryAlertThreshold(contract.DaysLeft)
+		if threshold == 0 {
+			continue
+		}
+		alert := models.ContractExpiryAlert{
+			ContractID: contract.ID,
+			DaysBefore: threshold,
+			EmployeeID: contract.EmployeeID,
+			ExpiresOn:  contract.EndDate,
+		}
+		var count int64
+		if err := config.GetDB().Model(&models.ContractExpiryAlert{}).
+			Where("contract_id = ? AND days_before = ?", contract.ID, threshold).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if err := config.GetDB().Create(&alert).Error; err != nil {
+			return err
+		}
+		notifyContractExpiry(contract, threshold)
+	}
+	return nil
+}
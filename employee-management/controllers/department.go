@@ -4,8 +4,10 @@ import (
 	"employee-management/config"
 	"employee-management/models"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // GetDepartments godoc
@@ -28,35 +30,50 @@ func GetDepartments(c *gin.Context) {
 
 // GetEmployeesByDepartment godoc
 // @Summary Get employees by department
-// @Description Retrieve all employees in a specific department
+// @Description Retrieve a page of employees in a specific department on a given day, including its sub-departments when recursive is true
 // @Tags Department
 // @Accept json
 // @Produce json
 // @Param department_id path int true "Department ID"
-// @Success 200 {array} models.Employee
+// @Param recursive query bool false "Include employees of all sub-departments"
+// @Param as_of query string false "Date (YYYY-MM-DD), defaults to today"
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Page size (max 100)"
+// @Success 200 {object} EmployeeSummaryPage
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/departments/{department_id}/employees [get]
 func GetEmployeesByDepartment(c *gin.Context) {
-	departmentID := c.Param("department_id")
-
-	var employees []models.Employee
-
-	// Assuming employee_departments is a junction table
-	if err := config.GetDB().Table("employee_departments").
-		Where("employee_departments.department_id = ?", departmentID).
-		Joins("JOIN employees ON employee_departments.employee_id = employees.id").
-		Select("employees.id, employees.name").
-		Find(&employees).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch employees for this department"})
+	departmentID, err := strconv.Atoi(c.Param("department_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid department ID"})
+		return
+	}
+	asOf, ok := parseAsOf(c)
+	if !ok {
+		return
+	}
+	var department models.Department
+	if err := config.GetDB().First(&department, departmentID).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Department not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, employees)
+	departmentIDs := []uint{department.ID}
+	if recursive, _ := strconv.ParseBool(c.Query("recursive")); recursive {
+		if departmentIDs, err = departmentSubtreeIDs(department.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch sub-departments"})
+			return
+		}
+	}
+
+	listMembers(c, "employee_departments", "department_id", departmentIDs, asOf)
 }
 
 // CreateDepartment godoc
 // @Summary Create a new department
-// @Description Add a new department record to the database
+// @Description Add a new department record to the database; head_id is ignored and set with PUT /api/v1/departments/{id}/head
 // @Tags Department
 // @Accept json
 // @Produce json
@@ -72,6 +89,12 @@ func CreateDepartment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
 		return
 	}
+	// The head is an approver, so it is only set through PUT /departments/:id/head
+	department.HeadID = nil
+	if err := validateDepartmentParent(0, department.ParentID); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
 
 	// Check if the department already exists
 	var existingDepartment models.Department
@@ -91,7 +114,7 @@ func CreateDepartment(c *gin.Context) {
 
 // UpdateDepartment godoc
 // @Summary Update a department
-// @Description Update an existing department's information
+// @Description Update an existing department's information; head_id is ignored and set with PUT /api/v1/departments/{id}/head
 // @Tags Department
 // @Accept json
 // @Produce json
@@ -112,10 +135,19 @@ func UpdateDepartment(c *gin.Context) {
 		return
 	}
 
+	departmentID, headID := department.ID, department.HeadID
 	if err := c.ShouldBindJSON(&department); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
 		return
 	}
+	// Always write the department in the path; the head is only changed through PUT /departments/:id/head
+	department.ID = departmentID
+	department.HeadID = headID
+	// Changing parent_id moves the whole subtree, so it must not create a cycle
+	if err := validateDepartmentParent(department.ID, department.ParentID); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
 
 	if err := config.GetDB().Save(&department).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update department"})
@@ -127,11 +159,12 @@ func UpdateDepartment(c *gin.Context) {
 
 // DeleteDepartment godoc
 // @Summary Delete a department
-// @Description Remove a department record from the database
+// @Description Remove a department record from the database. A department with employees can only be deleted when reassign_to names another department; its employees are moved there in the same transaction. Past membership history is kept and still shows the department name.
 // @Tags Department
 // @Accept json
 // @Produce json
 // @Param id path int true "Department ID"
+// @Param reassign_to query int false "Department that receives the employees"
 // @Success 200 {object} ResponseMessage
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -146,20 +179,65 @@ func DeleteDepartment(c *gin.Context) {
 		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Department not found"})
 		return
 	}
+	reassignTo, ok := parseReassignTo(c, department.ID)
+	if !ok {
+		return
+	}
+
+	// Sub-departments must be moved or deleted first
+	var children int64
+	if err := config.GetDB().Model(&models.Department{}).Where("parent_id = ?", department.ID).Count(&children).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check sub-departments"})
+		return
+	}
+	if children > 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Cannot delete department because it has sub-departments"})
+		return
+	}
 
 	// Check if any employees are assigned to the department
-	var employees []models.Employee
-	if err := config.GetDB().Where("department_id = ?", id).Find(&employees).Error; err != nil {
+	members, err := countMembers("employee_departments", "department_id", department.ID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check employees in department"})
 		return
 	}
-	if len(employees) > 0 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Cannot delete department because it has employees"})
+	if members > 0 && reassignTo == 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Cannot delete department because it has employees; pass reassign_to to move them"})
+		return
+	}
+	// Open vacancies must be filled or cancelled first
+	var vacancies int64
+	if err := config.GetDB().Model(&models.Vacancy{}).Where("department_id = ? AND status = ?", department.ID, models.VacancyOpen).Count(&vacancies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check vacancies in department"})
 		return
 	}
+	if vacancies > 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Cannot delete department because it has open vacancies"})
+		return
+	}
+	if reassignTo != 0 {
+		var target models.Department
+		if err := config.GetDB().First(&target, reassignTo).Error; err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Target department not found"})
+			return
+		}
+	}
 
-	// Delete the department
-	if err := config.GetDB().Delete(&department).Error; err != nil {
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		if reassignTo != 0 {
+			if err := reassignMembers(tx, "employee_departments", "department_id", department.ID, reassignTo); err != nil {
+				return err
+			}
+		}
+		if err := closeMemberships(tx, "employee_departments", "department_id", department.ID); err != nil {
+			return err
+		}
+		if err := tx.Where("department_id = ?", department.ID).Delete(&models.HeadcountPlan{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&department).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to delete department"})
 		return
 	}
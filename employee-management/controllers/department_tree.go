@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DepartmentNode là một phòng ban trong cây tổ chức kèm các phòng ban cấp dưới
+type DepartmentNode struct {
+	ID          uint             `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	ParentID    *uint            `json:"parent_id"`
+	Children    []DepartmentNode `json:"children"`
+}
+
+// MoveDepartmentRequest là phòng ban cấp trên mới khi chuyển một nhánh
+type MoveDepartmentRequest struct {
+	ParentID *uint `json:"parent_id"` // nil để đưa lên cấp cao nhất
+}
+
+// departmentChildren tải toàn bộ phòng ban và nhóm theo phòng ban cấp trên, 0 là cấp cao nhất
+func departmentChildren() (map[uint]models.Department, map[uint][]models.Department, error) {
+	var departments []models.Department
+	if err := config.GetDB().Order("name, id").Find(&departments).Error; err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[uint]models.Department, len(departments))
+	children := map[uint][]models.Department{}
+	for _, department := range departments {
+		byID[department.ID] = department
+		var parent uint
+		if department.ParentID != nil {
+			parent = *department.ParentID
+		}
+		children[parent] = append(children[parent], department)
+	}
+	return byID, children, nil
+}
+
+// departmentSubtreeIDs trả về ID của phòng ban và tất cả phòng ban cấp dưới
+func departmentSubtreeIDs(rootID uint) ([]uint, error) {
+	_, children, err := departmentChildren()
+	if err != nil {
+		return nil, err
+	}
+	ids := []uint{rootID}
+	seen := map[uint]bool{rootID: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child.ID] {
+				seen[child.ID] = true
+				ids = append(ids, child.ID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// validateDepartmentParent kiểm tra phòng ban cấp trên tồn tại và không tạo vòng lặp
+func validateDepartmentParent(departmentID uint, parentID *uint) error {
+	if parentID == nil {
+		return nil
+	}
+	if departmentID != 0 && *parentID == departmentID {
+		return fmt.Errorf("A department cannot be its own parent")
+	}
+	var parent models.Department
+	if err := config.GetDB().First(&parent, *parentID).Error; err != nil {
+		return fmt.Errorf("Parent department not found")
+	}
+	if departmentID == 0 {
+		return nil
+	}
+	subtree, err := departmentSubtreeIDs(departmentID)
+	if err != nil {
+		return err
+	}
+	for _, id := range subtree {
+		if id == *parentID {
+			return fmt.Errorf("Cannot move a department under one of its own sub-departments")
+		}
+	}
+	return nil
+}
+
+// buildDepartmentTree dựng cây phòng ban bắt đầu từ các phòng ban con của parentID
+func buildDepartmentTree(children map[uint][]models.Department, parentID uint, seen map[uint]bool) []DepartmentNode {
+	nodes := []DepartmentNode{}
+	for _, department := range children[parentID] {
+		// Dữ liệu lỗi có vòng lặp không được làm treo việc dựng cây
+		if seen[department.ID] {
+			continue
+		}
+		seen[department.ID] = true
+		nodes = append(nodes, DepartmentNode{
+			ID:          department.ID,
+			Name:        department.Name,
+			Description: department.Description,
+			ParentID:    department.ParentID,
+			Children:    buildDepartmentTree(children, department.ID, seen),
+		})
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes
+}
+
+// GetDepartmentTree godoc
+// @Summary Get the department tree
+// @Description Get departments as a nested tree of divisions, departments and teams, optionally starting from one department
+// @Tags Department
+// @Produce json
+// @Param root_id query int false "Root department ID"
+// @Success 200 {array} DepartmentNode
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/departments/tree [get]
+func GetDepartmentTree(c *gin.Context) {
+	byID, children, err := departmentChildren()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch departments"})
+		return
+	}
+
+	rootID := c.Query("root_id")
+	if rootID == "" {
+		c.JSON(http.StatusOK, buildDepartmentTree(children, 0, map[uint]bool{}))
+		return
+	}
+	id, err := strconv.Atoi(rootID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid department ID"})
+		return
+	}
+	root, ok := byID[uint(id)]
+	if !ok {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Department not found"})
+		return
+	}
+	c.JSON(http.StatusOK, []DepartmentNode{{
+		ID:          root.ID,
+		Name:        root.Name,
+		Description: root.Description,
+		ParentID:    root.ParentID,
+		Children:    buildDepartmentTree(children, root.ID, map[uint]bool{root.ID: true}),
+	}})
+}
+
+// MoveDepartment godoc
+// @Summary Move a department subtree
+// @Description Move a department together with all its sub-departments under another parent, or to the top level when parent_id is null
+// @Tags Department
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param request body MoveDepartmentRequest true "New parent"
+// @Success 200 {object} models.Department
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/departments/{id}/move [put]
+func MoveDepartment(c *gin.Context) {
+	var department models.Department
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&department).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Department not found"})
+		return
+	}
+
+	var request MoveDepartmentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	if err := validateDepartmentParent(department.ID, request.ParentID); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Các phòng ban cấp dưới vẫn giữ phòng ban cấp trên nên đi theo cả nhánh
+	department.ParentID = request.ParentID
+	if err := config.GetDB().Model(&department).Update("parent_id", request.ParentID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to move department"})
+		return
+	}
+	c.JSON(http.StatusOK, department)
+}
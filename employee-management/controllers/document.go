@@ -0,0 +1,398 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"employee-management/config"
+	"employee-management/models"
+	"employee-management/storage"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	documentStoreOnce sync.Once
+	documentStore     storage.Storage
+	documentStoreErr  error
+)
+
+// documentStorage khởi tạo backend lưu giấy tờ nhân viên một lần từ cấu hình DOCUMENT_STORAGE và S3_*
+func documentStorage() (storage.Storage, error) {
+	documentStoreOnce.Do(func() {
+		documentStore, documentStoreErr = storage.New(storage.EnvConfig())
+		if documentStoreErr != nil {
+			log.Printf("Document storage is not available: %v", documentStoreErr)
+		}
+	})
+	return documentStore, documentStoreErr
+}
+
+// documentMaxSize là dung lượng tối đa của một giấy tờ, cấu hình qua DOCUMENT_MAX_SIZE_MB
+func documentMaxSize() int64 {
+	if size, err := strconv.Atoi(config.GetEnv("DOCUMENT_MAX_SIZE_MB")); err == nil && size > 0 {
+		return int64(size) << 20
+	}
+	return 10 << 20
+}
+
+// containsString kiểm tra value có trong danh sách values
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ownerOrHRManager kiểm tra người thực hiện là chính nhân viên employeeID hoặc HR manager.
+// Nếu không hợp lệ, hàm tự trả lỗi về client và trả về false.
+func ownerOrHRManager(c *gin.Context, employeeID uint) (models.Employee, bool) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return actor, false
+	}
+	if actor.ID != employeeID && !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only the employee or an HR manager can access these documents"})
+		return actor, false
+	}
+	return actor, true
+}
+
+// sniffContentType đọc 512 byte đầu của tệp để xác định định dạng, không tin Content-Type do client gửi.
+// Trả về định dạng và luồng đọc lại toàn bộ tệp từ đầu.
+func sniffContentType(file io.Reader) (string, io.Reader, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", nil, err
+	}
+	head = head[:n]
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	return contentType, io.MultiReader(bytes.NewReader(head), file), nil
+}
+
+// documentExtensions là phần mở rộng lưu trữ theo định dạng, không dùng phần mở rộng do client đặt
+var documentExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+}
+
+// allowedContentTypeError là thông báo lỗi khi định dạng tệp không được chấp nhận
+func allowedContentTypeError(contentType string) ErrorResponse {
+	return ErrorResponse{Error: "File type " + contentType + " is not allowed. Must be one of: " + strings.Join(models.DocumentContentTypes, ", ")}
+}
+
+// storeDocument kiểm tra dung lượng và định dạng tệp tải lên, lưu tệp vào backend rồi ghi bản ghi giấy tờ.
+// document cần có sẵn EmployeeID, Category, Description và UploadedByID; các trường còn lại được điền ở đây.
+// Nếu không hợp lệ hoặc lỗi, hàm tự trả lỗi về client và trả về false.
+func storeDocument(c *gin.Context, fileHeader *multipart.FileHeader, document *models.EmployeeDocument) bool {
+	if fileHeader.Size == 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "File is empty"})
+		return false
+	}
+	if maxSize := documentMaxSize(); fileHeader.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{Error: fmt.Sprintf("File must not exceed %d MB", maxSize>>20)})
+		return false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Failed to read file"})
+		return false
+	}
+	defer file.Close()
+
+	contentType, content, err := sniffContentType(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Failed to read file"})
+		return false
+	}
+	if !containsString(models.DocumentContentTypes, contentType) {
+		c.JSON(http.StatusUnsupportedMediaType, allowedContentTypeError(contentType))
+		return false
+	}
+
+	store, err := documentStorage()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Document storage is not available"})
+		return false
+	}
+	hash := sha256.New()
+	body := io.TeeReader(content, hash)
+	key := models.DocumentStorageKey(document.EmployeeID, documentExtensions[contentType])
+	if err := store.Put(c.Request.Context(), key, body, fileHeader.Size, contentType); err != nil {
+		log.Printf("Failed to store document for employee %d: %v", document.EmployeeID, err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to store document"})
+		return false
+	}
+
+	document.FileName = filepath.Base(fileHeader.Filename)
+	document.ContentType = contentType
+	document.Size = fileHeader.Size
+	document.Checksum = hex.EncodeToString(hash.Sum(nil))
+	document.StorageKey = key
+	if err := config.GetDB().Create(document).Error; err != nil {
+		store.Delete(c.Request.Context(), key)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to save document"})
+		return false
+	}
+	return true
+}
+
+// discardDocument xóa giấy tờ vừa lưu khi bản ghi đính kèm nó không tạo được, lỗi chỉ được ghi log
+func discardDocument(ctx context.Context, document models.EmployeeDocument) {
+	if err := config.GetDB().Delete(&document).Error; err != nil {
+		log.Printf("Failed to delete document %d: %v", document.ID, err)
+		return
+	}
+	if store, err := documentStorage(); err == nil {
+		if err := store.Delete(ctx, document.StorageKey); err != nil {
+			log.Printf("Failed to delete file %s of document %d: %v", document.StorageKey, document.ID, err)
+		}
+	}
+}
+
+// serveDocument gửi nội dung giấy tờ về client kèm checksum đã lưu lúc tải lên
+func serveDocument(c *gin.Context, document models.EmployeeDocument) {
+	store, err := documentStorage()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Document storage is not available"})
+		return
+	}
+	reader, err := store.Open(c.Request.Context(), document.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Document file not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to open document %d: %v", document.ID, err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to read document"})
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, document.Size, document.ContentType, reader, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}),
+		"X-Checksum-SHA256":   document.Checksum,
+	})
+}
+
+// findDocumentOwner lấy nhân viên theo tham số id và kiểm tra người thực hiện là chính nhân viên đó hoặc HR manager.
+// Nếu không hợp lệ, hàm tự trả lỗi về client và trả về false.
+func findDocumentOwner(c *gin.Context) (models.Employee, models.Employee, bool) {
+	var employee models.Employee
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+		return employee, employee, false
+	}
+	actor, ok := ownerOrHRManager(c, uint(id))
+	if !ok {
+		return employee, actor, false
+	}
+	if err := config.GetDB().First(&employee, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return employee, actor, false
+	}
+	return employee, actor, true
+}
+
+// findEmployeeDocument lấy giấy tờ theo tham số document_id của nhân viên.
+// Nếu không tìm thấy, hàm tự trả lỗi về client và trả về false.
+func findEmployeeDocument(c *gin.Context, employeeID uint) (models.EmployeeDocument, bool) {
+	var document models.EmployeeDocument
+	if err := config.GetDB().Where("id = ? AND employee_id = ?", c.Param("document_id"), employeeID).First(&document).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Document not found"})
+		return document, false
+	}
+	return document, true
+}
+
+// GetEmployeeDocuments godoc
+// @Summary List an employee's documents
+// @Description The employee or an HR manager lists the stored documents of an employee, newest first
+// @Tags Document
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param category query string false "Category (id_card/degree/contract/receipt/other)"
+// @Success 200 {array} models.EmployeeDocument
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/documents [get]
+func GetEmployeeDocuments(c *gin.Context) {
+	employee, _, ok := findDocumentOwner(c)
+	if !ok {
+		return
+	}
+
+	query := config.GetDB().Where("employee_id = ?", employee.ID)
+	if category := c.Query("category"); category != "" {
+		if !containsString(models.DocumentCategories, category) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "category must be one of: " + strings.Join(models.DocumentCategories, ", ")})
+			return
+		}
+		query = query.Where("category = ?", category)
+	}
+	var documents []models.EmployeeDocument
+	if err := query.Order("created_at DESC, id DESC").Find(&documents).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch documents"})
+		return
+	}
+	c.JSON(http.StatusOK, documents)
+}
+
+// UploadEmployeeDocument godoc
+// @Summary Upload an employee document
+// @Description The employee or an HR manager uploads an ID card scan, degree, contract or receipt. The file type is detected from its content and must be PDF, JPEG, PNG or WebP; a SHA-256 checksum is stored with it.
+// @Tags Document
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param category formData string true "Category (id_card/degree/contract/receipt/other)"
+// @Param description formData string false "Description"
+// @Param file formData file true "Document"
+// @Success 201 {object} models.EmployeeDocument
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/documents [post]
+func UploadEmployeeDocument(c *gin.Context) {
+	employee, actor, ok := findDocumentOwner(c)
+	if !ok {
+		return
+	}
+
+	category := strings.TrimSpace(c.PostForm("category"))
+	if !containsString(models.DocumentCategories, category) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "category must be one of: " + strings.Join(models.DocumentCategories, ", ")})
+		return
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "A file is required"})
+		return
+	}
+
+	document := models.EmployeeDocument{
+		EmployeeID:   employee.ID,
+		Category:     category,
+		Description:  c.PostForm("description"),
+		UploadedByID: actor.ID,
+	}
+	if !storeDocument(c, fileHeader, &document) {
+		return
+	}
+	c.JSON(http.StatusCreated, document)
+}
+
+// DownloadEmployeeDocument godoc
+// @Summary Download an employee document
+// @Description The employee or an HR manager downloads a stored document. The X-Checksum-SHA256 header carries the checksum recorded at upload.
+// @Tags Document
+// @Produce octet-stream
+// @Param id path int true "Employee ID"
+// @Param document_id path int true "Document ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/documents/{document_id} [get]
+func DownloadEmployeeDocument(c *gin.Context) {
+	employee, _, ok := findDocumentOwner(c)
+	if !ok {
+		return
+	}
+	document, ok := findEmployeeDocument(c, employee.ID)
+	if !ok {
+		return
+	}
+	serveDocument(c, document)
+}
+
+// DeleteEmployeeDocument godoc
+// @Summary Delete an employee document
+// @Description An HR manager deletes a stored document and its file. Signed contracts and expense receipts stay with the contract or claim they are attached to and cannot be deleted here.
+// @Tags Document
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param document_id path int true "Document ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {object} ResponseMessage
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/documents/{document_id} [delete]
+func DeleteEmployeeDocument(c *gin.Context) {
+	employee, actor, ok := findDocumentOwner(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can delete documents"})
+		return
+	}
+	document, ok := findEmployeeDocument(c, employee.ID)
+	if !ok {
+		return
+	}
+	// Bản hợp đồng và chứng từ chi phí được xóa cùng bản ghi đính kèm, không xóa riêng ở đây
+	var contracts, claims int64
+	if err := config.GetDB().Model(&models.EmploymentContract{}).Where("document_id = ?", document.ID).Count(&contracts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check document usage"})
+		return
+	}
+	if err := config.GetDB().Model(&models.ExpenseClaim{}).Where("receipt_document_id = ?", document.ID).Count(&claims).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check document usage"})
+		return
+	}
+	if contracts > 0 || claims > 0 {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Document is attached to a contract or expense claim"})
+		return
+	}
+
+	store, err := documentStorage()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Document storage is not available"})
+		return
+	}
+	if err := config.GetDB().Delete(&document).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to delete document"})
+		return
+	}
+	// Bản ghi đã xóa nên tệp còn sót lại chỉ được ghi log để dọn sau
+	if err := store.Delete(c.Request.Context(), document.StorageKey); err != nil {
+		log.Printf("Failed to delete file %s of document %d: %v", document.StorageKey, document.ID, err)
+	}
+	c.JSON(http.StatusOK, ResponseMessage{Message: "Document deleted successfully"})
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
@@ -22,6 +23,28 @@ type ResponseMessage struct {
 	Message string `json:"message"`
 }
 
+// EmployeeSummary is a trimmed employee profile embedded in other responses, without credentials or personal data
+type EmployeeSummary struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	Status    string `json:"status"`
+	ManagerID *uint  `json:"manager_id,omitempty"`
+}
+
+// summarizeEmployee builds the trimmed profile of an employee
+func summarizeEmployee(employee models.Employee) EmployeeSummary {
+	return EmployeeSummary{
+		ID:        employee.ID,
+		Name:      employee.Name,
+		Email:     employee.Email,
+		Role:      employee.Role,
+		Status:    employee.Status,
+		ManagerID: employee.ManagerID,
+	}
+}
+
 // HashPassword hashes a plain text password
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -59,6 +82,8 @@ func RegisterEmployee(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
 		return
 	}
+	// Quản lý trực tiếp do HR thiết lập sau khi đăng ký
+	employee.ManagerID = nil
 
 	// Check if email is already in use
 	var existingEmployee models.Employee
@@ -125,7 +150,7 @@ func LoginEmployee(c *gin.Context) {
 	}
 
 	var employee models.Employee
-	if err := config.GetDB().Where("email = ?", loginData.Email).Preload("EmployeeDepartments").Preload("EmployeePositions").First(&employee).Error; err != nil {
+	if err := config.GetDB().Where("email = ?", loginData.Email).Preload("EmployeeDepartments", activeDepartments).Preload("EmployeePositions", activePositions).First(&employee).Error; err != nil {
 		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
 		return
 	}
@@ -183,8 +208,8 @@ func LoginEmployee(c *gin.Context) {
 func GetEmployees(c *gin.Context) {
 	var employees []models.Employee
 
-	// Sử dụng Preload để tải thông tin các department và position của mỗi nhân viên
-	if err := config.GetDB().Preload("EmployeeDepartments").Preload("EmployeePositions").Find(&employees).Error; err != nil {
+	// Sử dụng Preload để tải thông tin các department và position hiện tại của mỗi nhân viên
+	if err := config.GetDB().Preload("EmployeeDepartments", activeDepartments).Preload("EmployeePositions", activePositions).Find(&employees).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch employees"})
 		return
 	}
@@ -208,13 +233,16 @@ func GetEmployees(c *gin.Context) {
 
 // CreateEmployee godoc
 // @Summary Create a new employee
-// @Description Add a new employee record to the database
+// @Description Add a new employee record to the database. Setting manager_id requires an HR manager.
 // @Tags Employee
 // @Accept json
 // @Produce json
+// @Param X-Employee-ID header int false "Actor employee ID, required when manager_id is set"
 // @Param employee body models.Employee true "Employee data"
 // @Success 201 {object} models.Employee
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/employees [post]
 func CreateEmployee(c *gin.Context) {
@@ -225,6 +253,13 @@ func CreateEmployee(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Invalid input data: %v", err)})
 		return
 	}
+	if employee.ManagerID != nil && !authorizeManagerChange(c) {
+		return
+	}
+	if err := validateManager(0, employee.ManagerID); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
 
 	// Insert the main employee record first to get employee.ID
 	if err := config.GetDB().Create(&employee).Error; err != nil {
@@ -232,9 +267,16 @@ func CreateEmployee(c *gin.Context) {
 		return
 	}
 
+	// Phòng ban và chức vụ có hiệu lực từ ngày vào làm, phần tử đầu tiên là phòng ban/chức vụ chính
+	startDate := time.Now()
+	if !employee.HireDate.IsZero() {
+		startDate = employee.HireDate.Time
+	}
+
 	// Validate and handle department IDs
 	if len(employee.DepartmentIDs) > 0 {
-		for _, deptID := range employee.DepartmentIDs {
+		allocations := splitAllocation(len(employee.DepartmentIDs))
+		for i, deptID := range employee.DepartmentIDs {
 			var department models.Department
 			if err := config.GetDB().Where("id = ?", deptID).First(&department).Error; err != nil {
 				c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid department ID"})
@@ -242,8 +284,11 @@ func CreateEmployee(c *gin.Context) {
 			}
 			// Insert into employee_departments table
 			if err := config.GetDB().Create(&models.EmployeeDepartment{
-				EmployeeID:   employee.ID,
-				DepartmentID: deptID,
+				EmployeeID:        employee.ID,
+				DepartmentID:      deptID,
+				StartDate:         &startDate,
+				IsPrimary:         i == 0,
+				AllocationPercent: allocations[i],
 			}).Error; err != nil {
 				c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to add employee to department"})
 				return
@@ -253,7 +298,8 @@ func CreateEmployee(c *gin.Context) {
 
 	// Validate and handle position IDs
 	if len(employee.PositionIDs) > 0 {
-		for _, posID := range employee.PositionIDs {
+		allocations := splitAllocation(len(employee.PositionIDs))
+		for i, posID := range employee.PositionIDs {
 			var position models.Position
 			if err := config.GetDB().Where("id = ?", posID).First(&position).Error; err != nil {
 				c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid position ID"})
@@ -261,8 +307,11 @@ func CreateEmployee(c *gin.Context) {
 			}
 			// Insert into employee_positions table
 			if err := config.GetDB().Create(&models.EmployeePosition{
-				EmployeeID: employee.ID,
-				PositionID: posID,
+				EmployeeID:        employee.ID,
+				PositionID:        posID,
+				StartDate:         &startDate,
+				IsPrimary:         i == 0,
+				AllocationPercent: allocations[i],
 			}).Error; err != nil {
 				c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to add employee to position"})
 				return
@@ -276,14 +325,17 @@ func CreateEmployee(c *gin.Context) {
 
 // UpdateEmployee godoc
 // @Summary Update an employee
-// @Description Update details of an existing employee, including multiple departments and positions
+// @Description Update details of an existing employee, including multiple departments and positions. Changing manager_id requires an HR manager.
 // @Tags Employee
 // @Accept json
 // @Produce json
 // @Param id path int true "Employee ID"
+// @Param X-Employee-ID header int false "Actor employee ID, required when manager_id changes"
 // @Param employee body models.Employee true "Updated employee data"
 // @Success 200 {object} models.Employee
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/employees/{id} [put]
@@ -298,10 +350,25 @@ func UpdateEmployee(c *gin.Context) {
 	}
 
 	// Bind updated data
+	// Sao chép giá trị vì JSON ghi đè qua con trỏ ManagerID sẵn có
+	var previousManagerID *uint
+	if employee.ManagerID != nil {
+		id := *employee.ManagerID
+		previousManagerID = &id
+	}
 	if err := c.ShouldBindJSON(&employee); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
 		return
 	}
+	if !sameManager(previousManagerID, employee.ManagerID) && !authorizeManagerChange(c) {
+		return
+	}
+
+	// The reporting line must not loop back to this employee
+	if err := validateManager(employee.ID, employee.ManagerID); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
 
 	// Validate departments and positions as done in CreateEmployee
 	// Same logic for department and position validation as in CreateEmployee function...
@@ -0,0 +1,627 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"employee-management/payroll"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PerDiemRuleInput là dữ liệu tạo hoặc cập nhật mức phụ cấp công tác
+type PerDiemRuleInput struct {
+	Destination string `json:"destination"` // Để trống là mức mặc định
+	MinDays     int    `json:"min_days"`    // Mặc định 1
+	DailyAmount int    `json:"daily_amount" binding:"required"`
+}
+
+// validExpenseCategory kiểm tra loại chi phí nhân viên được tự khai
+func validExpenseCategory(category string) bool {
+	for _, allowed := range models.ExpenseCategories {
+		if category == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// findAssignmentForExpense lấy công tác và kiểm tra người thực hiện được khai chi phí cho công tác này.
+// Nếu không hợp lệ, hàm tự trả lỗi về client và trả về false.
+func findAssignmentForExpense(c *gin.Context, actor models.Employee) (models.WorkAssignment, bool) {
+	var assignment models.WorkAssignment
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&assignment).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Work assignment not found"})
+		return assignment, false
+	}
+	if !canManageAssignment(actor, assignment) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only the assignee or a manager can claim expenses for this work assignment"})
+		return assignment, false
+	}
+	if assignment.Status == models.AssignmentCancelled {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Cannot claim expenses for a cancelled work assignment"})
+		return assignment, false
+	}
+	return assignment, true
+}
+
+// reimbursementEarnings gom các khoản chi phí đã duyệt chưa hoàn trả thành dòng thu nhập không chịu thuế
+func reimbursementEarnings(employeeID uint) ([]models.SalaryEarningLine, []uint, int, error) {
+	var claims []models.ExpenseClaim
+	if err := config.GetDB().
+		Where("employee_id = ? AND status = ? AND salary_id IS NULL", employeeID, models.RequestApproved).
+		Order("id").
+		Find(&claims).Error; err != nil {
+		return nil, nil, 0, err
+	}
+
+	lines := []models.SalaryEarningLine{}
+	index := map[string]int{}
+	ids := make([]uint, 0, len(claims))
+	total := 0
+	for _, claim := range claims {
+		ids = append(ids, claim.ID)
+		i, ok := index[claim.Category]
+		if !ok {
+			lines = append(lines, models.SalaryEarningLine{
+				Type:        models.EarningReimbursement,
+				Category:    claim.Category,
+				Description: "Reimbursement " + claim.Category,
+				NonTaxable:  true,
+			})
+			i = len(lines) - 1
+			index[claim.Category] = i
+		}
+		lines[i].Amount += claim.AmountVND
+		total += claim.AmountVND
+	}
+	return lines, ids, total, nil
+}
+
+// applyReimbursements đánh dấu các khoản chi phí đã được hoàn trả qua bảng lương
+func applyReimbursements(tx *gorm.DB, salaryID uint, claimIDs []uint) error {
+	if len(claimIDs) == 0 {
+		return nil
+	}
+	return tx.Model(&models.ExpenseClaim{}).Where("id IN ?", claimIDs).
+		Updates(map[string]interface{}{"salary_id": salaryID, "status": models.ExpenseReimbursed}).Error
+}
+
+// revertReimbursements trả các khoản chi phí về trạng thái đã duyệt khi bảng lương nháp bị xóa.
+// Khoản đã hoàn trả qua bảng lương đã duyệt hoặc đã trả không được đưa về để trả lần nữa.
+func revertReimbursements(tx *gorm.DB, salaryID uint) error {
+	if err := requireDraftSalary(tx, salaryID); err != nil {
+		return err
+	}
+	return tx.Model(&models.ExpenseClaim{}).Where("salary_id = ?", salaryID).
+		Updates(map[string]interface{}{"salary_id": nil, "status": models.RequestApproved}).Error
+}
+
+// GetPerDiemRules godoc
+// @Summary Get per-diem rules
+// @Description List daily business trip allowances by destination and minimum trip length
+// @Tags Expense
+// @Produce json
+// @Success 200 {array} models.PerDiemRule
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/per-diem-rules [get]
+func GetPerDiemRules(c *gin.Context) {
+	var rules []models.PerDiemRule
+	if err := config.GetDB().Order("destination, min_days").Find(&rules).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch per-diem rules"})
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+// CreatePerDiemRule godoc
+// @Summary Create a per-diem rule
+// @Description An HR manager adds a daily allowance for a destination; an empty destination is the default rule
+// @Tags Expense
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body PerDiemRuleInput true "Per-diem rule"
+// @Success 201 {object} models.PerDiemRule
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/per-diem-rules [post]
+func CreatePerDiemRule(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage per-diem rules"})
+		return
+	}
+
+	var input PerDiemRuleInput
+	if err := c.ShouldBindJSON(&input); err != nil || input.DailyAmount <= 0 || input.MinDays < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	if input.MinDays == 0 {
+		input.MinDays = 1
+	}
+
+	rule := models.PerDiemRule{
+		Destination: strings.TrimSpace(input.Destination),
+		MinDays:     input.MinDays,
+		DailyAmount: input.DailyAmount,
+	}
+	var existing models.PerDiemRule
+	if err := config.GetDB().Where("LOWER(destination) = LOWER(?) AND min_days = ?", rule.Destination, rule.MinDays).First(&existing).Error; err == nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "A per-diem rule for this destination and minimum days already exists"})
+		return
+	}
+	if err := config.GetDB().Create(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create per-diem rule"})
+		return
+	}
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdatePerDiemRule godoc
+// @Summary Update a per-diem rule
+// @Description An HR manager changes a daily allowance; claims already created keep their amount
+// @Tags Expense
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body PerDiemRuleInput true "Per-diem rule"
+// @Success 200 {object} models.PerDiemRule
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/per-diem-rules/{id} [put]
+func UpdatePerDiemRule(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage per-diem rules"})
+		return
+	}
+
+	var rule models.PerDiemRule
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&rule).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Per-diem rule not found"})
+		return
+	}
+
+	var input PerDiemRuleInput
+	if err := c.ShouldBindJSON(&input); err != nil || input.DailyAmount <= 0 || input.MinDays < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	if input.MinDays == 0 {
+		input.MinDays = 1
+	}
+
+	destination := strings.TrimSpace(input.Destination)
+	var existing models.PerDiemRule
+	if err := config.GetDB().Where("LOWER(destination) = LOWER(?) AND min_days = ? AND id <> ?", destination, input.MinDays, rule.ID).First(&existing).Error; err == nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "A per-diem rule for this destination and minimum days already exists"})
+		return
+	}
+
+	rule.Destination = destination
+	rule.MinDays = input.MinDays
+	rule.DailyAmount = input.DailyAmount
+	if err := config.GetDB().Save(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update per-diem rule"})
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeletePerDiemRule godoc
+// @Summary Delete a per-diem rule
+// @Description An HR manager removes a daily allowance rule
+// @Tags Expense
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {object} ResponseMessage
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/per-diem-rules/{id} [delete]
+func DeletePerDiemRule(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage per-diem rules"})
+		return
+	}
+
+	var rule models.PerDiemRule
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&rule).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Per-diem rule not found"})
+		return
+	}
+	if err := config.GetDB().Delete(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to delete per-diem rule"})
+		return
+	}
+	c.JSON(http.StatusOK, ResponseMessage{Message: "Per-diem rule deleted successfully"})
+}
+
+// GetExpenseClaims godoc
+// @Summary Get expense claims
+// @Description List business trip expense claims, optionally filtered by employee, work assignment and status
+// @Tags Expense
+// @Produce json
+// @Param employee_id query int false "Employee ID"
+// @Param work_assignment_id query int false "Work assignment ID"
+// @Param status query string false "Status (pending/approved/rejected/reimbursed)"
+// @Success 200 {array} models.ExpenseClaim
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/expenses [get]
+func GetExpenseClaims(c *gin.Context) {
+	query := config.GetDB()
+
+	if employeeID := c.Query("employee_id"); employeeID != "" {
+		id, err := strconv.Atoi(employeeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+			return
+		}
+		query = query.Where("employee_id = ?", id)
+	}
+	if assignmentID := c.Query("work_assignment_id"); assignmentID != "" {
+		id, err := strconv.Atoi(assignmentID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid work assignment ID"})
+			return
+		}
+		query = query.Where("work_assignment_id = ?", id)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var claims []models.ExpenseClaim
+	if err := query.Order("id DESC").Find(&claims).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch expense claims"})
+		return
+	}
+	c.JSON(http.StatusOK, claims)
+}
+
+// GetWorkAssignmentExpenses godoc
+// @Summary Get expense claims of a work assignment
+// @Description List all expense claims attached to a work assignment
+// @Tags Expense
+// @Produce json
+// @Param id path int true "Work assignment ID"
+// @Success 200 {array} models.ExpenseClaim
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workassignments/{id}/expenses [get]
+func GetWorkAssignmentExpenses(c *gin.Context) {
+	var assignment models.WorkAssignment
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&assignment).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Work assignment not found"})
+		return
+	}
+
+	var claims []models.ExpenseClaim
+	if err := config.GetDB().Where("work_assignment_id = ?", assignment.ID).Order("id").Find(&claims).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch expense claims"})
+		return
+	}
+	c.JSON(http.StatusOK, claims)
+}
+
+// CreateExpenseClaim godoc
+// @Summary Claim a business trip expense
+// @Description The assignee (or a manager) claims an expense for a work assignment with an optional receipt. Foreign currency amounts need an exchange rate to VND.
+// @Tags Expense
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Work assignment ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param category formData string true "Category (transport/lodging/meals/other)"
+// @Param amount formData number true "Amount in the given currency"
+// @Param currency formData string false "Currency" default(VND)
+// @Param exchange_rate formData number false "Exchange rate to VND, required for foreign currency"
+// @Param description formData string false "Description"
+// @Param receipt formData file false "Receipt (PDF, JPEG, PNG or WebP)"
+// @Success 201 {object} models.ExpenseClaim
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workassignments/{id}/expenses [post]
+func CreateExpenseClaim(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	assignment, ok := findAssignmentForExpense(c, actor)
+	if !ok {
+		return
+	}
+
+	category := strings.TrimSpace(c.PostForm("category"))
+	if !validExpenseCategory(category) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "category must be one of: " + strings.Join(models.ExpenseCategories, ", ")})
+		return
+	}
+	amount, err := strconv.ParseFloat(c.PostForm("amount"), 64)
+	if err != nil || amount <= 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "A positive amount is required"})
+		return
+	}
+	currency := strings.ToUpper(strings.TrimSpace(c.DefaultPostForm("currency", models.DefaultCurrency)))
+	exchangeRate := 1.0
+	if currency != models.DefaultCurrency {
+		exchangeRate, err = strconv.ParseFloat(c.PostForm("exchange_rate"), 64)
+		if err != nil || exchangeRate <= 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "A positive exchange_rate to " + models.DefaultCurrency + " is required for foreign currency"})
+			return
+		}
+	}
+
+	claim := models.ExpenseClaim{
+		WorkAssignmentID: assignment.ID,
+		EmployeeID:       assignment.EmployeeID,
+		Category:         category,
+		Description:      c.PostForm("description"),
+		Amount:           amount,
+		Currency:         currency,
+		ExchangeRate:     exchangeRate,
+		AmountVND:        payroll.ConvertAmount(amount, exchangeRate),
+		CreatedByID:      actor.ID,
+		Status:           models.RequestPending,
+	}
+
+	// Chứng từ là tùy chọn, nếu có thì lưu thành giấy tờ loại receipt trước khi tạo khoản chi phí
+	var receipt *models.EmployeeDocument
+	if fileHeader, err := c.FormFile("receipt"); err == nil {
+		receipt = &models.EmployeeDocument{
+			EmployeeID:   assignment.EmployeeID,
+			Category:     models.DocumentReceipt,
+			Description:  fmt.Sprintf("Receipt for work assignment %d", assignment.ID),
+			UploadedByID: actor.ID,
+		}
+		if !storeDocument(c, fileHeader, receipt) {
+			return
+		}
+		claim.ReceiptDocumentID = &receipt.ID
+	} else if !errors.Is(err, http.ErrMissingFile) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid receipt upload"})
+		return
+	}
+
+	if err := config.GetDB().Create(&claim).Error; err != nil {
+		if receipt != nil {
+			discardDocument(c.Request.Context(), *receipt)
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create expense claim"})
+		return
+	}
+	c.JSON(http.StatusCreated, claim)
+}
+
+// ClaimPerDiem godoc
+// @Summary Claim per-diem for a work assignment
+// @Description Create a per-diem claim from the rule matching the assignment destination and trip length. Only one active per-diem claim is allowed per assignment.
+// @Tags Expense
+// @Produce json
+// @Param id path int true "Work assignment ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 201 {object} models.ExpenseClaim
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workassignments/{id}/per-diem [post]
+func ClaimPerDiem(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	assignment, ok := findAssignmentForExpense(c, actor)
+	if !ok {
+		return
+	}
+
+	var existing int64
+	if err := config.GetDB().Model(&models.ExpenseClaim{}).
+		Where("work_assignment_id = ? AND category = ? AND status <> ?", assignment.ID, models.ExpensePerDiem, models.RequestRejected).
+		Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check existing per-diem claims"})
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Per-diem has already been claimed for this work assignment"})
+		return
+	}
+
+	var rules []models.PerDiemRule
+	if err := config.GetDB().Find(&rules).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch per-diem rules"})
+		return
+	}
+	rates := make([]payroll.PerDiemRate, 0, len(rules))
+	for _, rule := range rules {
+		rates = append(rates, payroll.PerDiemRate{Destination: rule.Destination, MinDays: rule.MinDays, DailyAmount: rule.DailyAmount})
+	}
+
+	days := payroll.TripDays(assignment.StartDate.Time, assignment.EndDate.Time)
+	rate, found := payroll.SelectPerDiemRate(rates, assignment.Destination, days)
+	if !found {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "No per-diem rule applies to this destination and trip length"})
+		return
+	}
+
+	amount := rate.DailyAmount * days
+	claim := models.ExpenseClaim{
+		WorkAssignmentID: assignment.ID,
+		EmployeeID:       assignment.EmployeeID,
+		Category:         models.ExpensePerDiem,
+		Description:      fmt.Sprintf("Per-diem %d day(s) x %d", days, rate.DailyAmount),
+		Amount:           float64(amount),
+		Currency:         models.DefaultCurrency,
+		ExchangeRate:     1,
+		AmountVND:        amount,
+		CreatedByID:      actor.ID,
+		Status:           models.RequestPending,
+	}
+	if err := config.GetDB().Create(&claim).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create per-diem claim"})
+		return
+	}
+	c.JSON(http.StatusCreated, claim)
+}
+
+// GetExpenseReceipt godoc
+// @Summary Download an expense receipt
+// @Description The claimant, a manager who can approve the claim, its reviewer or finance downloads the receipt attached to an expense claim
+// @Tags Expense
+// @Produce octet-stream
+// @Param id path int true "Expense claim ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {file} file
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/expenses/{id}/receipt [get]
+func GetExpenseReceipt(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	var claim models.ExpenseClaim
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&claim).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Expense claim not found"})
+		return
+	}
+	reviewer := claim.ReviewedByID != nil && *claim.ReviewedByID == actor.ID
+	if claim.EmployeeID != actor.ID && !reviewer && !canApproveFor(actor, claim.EmployeeID) && !hasRole(actor, financeRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only the claimant, an approver or finance can view this receipt"})
+		return
+	}
+	var receipt models.EmployeeDocument
+	if claim.ReceiptDocumentID == nil || config.GetDB().First(&receipt, *claim.ReceiptDocumentID).Error != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Expense claim has no receipt"})
+		return
+	}
+	serveDocument(c, receipt)
+}
+
+// ApproveExpenseClaim godoc
+// @Summary Approve an expense claim
+// @Description A manager or the requester's manager in the reporting line approves a pending expense claim; it is reimbursed on the employee's next generated salary as a non-taxable earnings line
+// @Tags Expense
+// @Accept json
+// @Produce json
+// @Param id path int true "Expense claim ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Optional comment"
+// @Success 200 {object} models.ExpenseClaim
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/expenses/{id}/approve [post]
+func ApproveExpenseClaim(c *gin.Context) {
+	reviewExpenseClaim(c, models.RequestApproved)
+}
+
+// RejectExpenseClaim godoc
+// @Summary Reject an expense claim
+// @Description A manager or the requester's manager in the reporting line rejects a pending expense claim
+// @Tags Expense
+// @Accept json
+// @Produce json
+// @Param id path int true "Expense claim ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Optional comment"
+// @Success 200 {object} models.ExpenseClaim
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/expenses/{id}/reject [post]
+func RejectExpenseClaim(c *gin.Context) {
+	reviewExpenseClaim(c, models.RequestRejected)
+}
+
+// reviewExpenseClaim duyệt hoặc từ chối khoản chi phí đang chờ
+func reviewExpenseClaim(c *gin.Context, status string) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	var body SalaryApprovalRequest
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	var claim models.ExpenseClaim
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&claim).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Expense claim not found"})
+		return
+	}
+	if claim.Status != models.RequestPending {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Expense claim is not pending"})
+		return
+	}
+	if claim.EmployeeID == actor.ID {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "You cannot review your own expense claim"})
+		return
+	}
+	if claim.CreatedByID == actor.ID {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "You cannot review an expense claim you filed"})
+		return
+	}
+	if !canApproveFor(actor, claim.EmployeeID) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can review expense claims"})
+		return
+	}
+
+	now := time.Now()
+	claim.Status = status
+	claim.ReviewedByID = &actor.ID
+	claim.ReviewedAt = &now
+	claim.ReviewComment = body.Comment
+	if err := config.GetDB().Save(&claim).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update expense claim"})
+		return
+	}
+	c.JSON(http.StatusOK, claim)
+}
@@ -0,0 +1,600 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HeadcountPlanInput là định biên của một chức vụ trong phòng ban
+type HeadcountPlanInput struct {
+	PositionID   uint   `json:"position_id" binding:"required"`
+	PlannedCount int    `json:"planned_count"`
+	Note         string `json:"note"`
+}
+
+// VacancyInput là dữ liệu mở một vị trí tuyển dụng
+type VacancyInput struct {
+	DepartmentID         uint              `json:"department_id" binding:"required"`
+	PositionID           uint              `json:"position_id" binding:"required"`
+	Title                string            `json:"title"` // Mặc định là tên chức vụ
+	Description          string            `json:"description"`
+	RecruitmentReference string            `json:"recruitment_reference"`
+	TargetStartDate      models.CustomTime `json:"target_start_date"`
+}
+
+// FillVacancyRequest là nhân viên nhận vị trí tuyển dụng
+type FillVacancyRequest struct {
+	EmployeeID uint `json:"employee_id" binding:"required"`
+}
+
+// HeadcountLine so sánh định biên và số người thực tế của một chức vụ trong phòng ban
+type HeadcountLine struct {
+	PositionID    uint   `json:"position_id"`
+	PositionTitle string `json:"position_title"`
+	Planned       int    `json:"planned"`
+	Filled        int    `json:"filled"`
+	Open          int    `json:"open"`           // Số chỗ còn trống trong định biên
+	Over          int    `json:"over"`           // Số người vượt định biên
+	OpenVacancies int    `json:"open_vacancies"` // Số vị trí đang tuyển
+}
+
+// DepartmentHeadcount là định biên, số người thực tế và chỗ trống của một phòng ban
+type DepartmentHeadcount struct {
+	DepartmentID   uint            `json:"department_id"`
+	DepartmentName string          `json:"department_name"`
+	Planned        int             `json:"planned"`
+	Filled         int             `json:"filled"`
+	Open           int             `json:"open"`
+	OpenVacancies  int             `json:"open_vacancies"`
+	Positions      []HeadcountLine `json:"positions"`
+}
+
+// errNoHeadcount báo không còn chỗ trống trong định biên để mở thêm vị trí tuyển dụng
+var errNoHeadcount = errors.New("No approved headcount left for this position in the department")
+
+// filledCounts đếm số nhân viên giữ từng chức vụ có phòng ban chính là phòng ban này.
+// Nhân viên kiêm nhiệm nhiều phòng ban chỉ chiếm định biên ở phòng ban chính.
+func filledCounts(tx *gorm.DB, departmentID uint) (map[uint]int, error) {
+	var rows []struct {
+		PositionID  uint
+		FilledCount int
+	}
+	err := activePositions(tx.Table("employee_positions")).
+		Select("employee_positions.position_id, COUNT(DISTINCT employee_positions.employee_id) AS filled_count").
+		Where("employee_positions.employee_id IN (?)",
+			activeDepartments(tx.Table("employee_departments").Select("employee_id").
+				Where("department_id = ? AND is_primary = ?", departmentID, true))).
+		Group("employee_positions.position_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := map[uint]int{}
+	for _, row := range rows {
+		counts[row.PositionID] = row.FilledCount
+	}
+	return counts, nil
+}
+
+// openVacancyCounts đếm số vị trí đang tuyển của từng chức vụ trong phòng ban
+func openVacancyCounts(tx *gorm.DB, departmentID uint) (map[uint]int, error) {
+	var rows []struct {
+		PositionID uint
+		OpenCount  int
+	}
+	err := tx.Model(&models.Vacancy{}).
+		Select("position_id, COUNT(*) AS open_count").
+		Where("department_id = ? AND status = ?", departmentID, models.VacancyOpen).
+		Group("position_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := map[uint]int{}
+	for _, row := range rows {
+		counts[row.PositionID] = row.OpenCount
+	}
+	return counts, nil
+}
+
+// departmentHeadcount tổng hợp định biên, số người thực tế và vị trí đang tuyển theo chức vụ
+func departmentHeadcount(department models.Department) (DepartmentHeadcount, error) {
+	result := DepartmentHeadcount{DepartmentID: department.ID, DepartmentName: department.Name, Positions: []HeadcountLine{}}
+
+	var plans []models.HeadcountPlan
+	if err := config.GetDB().Where("department_id = ?", department.ID).Find(&plans).Error; err != nil {
+		return result, err
+	}
+	filled, err := filledCounts(config.GetDB(), department.ID)
+	if err != nil {
+		return result, err
+	}
+	vacancies, err := openVacancyCounts(config.GetDB(), department.ID)
+	if err != nil {
+		return result, err
+	}
+
+	lines := map[uint]*HeadcountLine{}
+	line := func(positionID uint) *HeadcountLine {
+		if lines[positionID] == nil {
+			lines[positionID] = &HeadcountLine{PositionID: positionID}
+		}
+		return lines[positionID]
+	}
+	for _, plan := range plans {
+		line(plan.PositionID).Planned = plan.PlannedCount
+	}
+	for positionID, count := range filled {
+		line(positionID).Filled = count
+	}
+	for positionID, count := range vacancies {
+		line(positionID).OpenVacancies = count
+	}
+
+	positionIDs := make([]uint, 0, len(lines))
+	for positionID := range lines {
+		positionIDs = append(positionIDs, positionID)
+	}
+	var positions []models.Position
+	if err := config.GetDB().Where("id IN ?", positionIDs).Find(&positions).Error; err != nil {
+		return result, err
+	}
+	for _, position := range positions {
+		lines[position.ID].PositionTitle = position.Title
+	}
+
+	for _, l := range lines {
+		if l.Filled < l.Planned {
+			l.Open = l.Planned - l.Filled
+		} else {
+			l.Over = l.Filled - l.Planned
+		}
+		result.Planned += l.Planned
+		result.Filled += l.Filled
+		result.Open += l.Open
+		result.OpenVacancies += l.OpenVacancies
+		result.Positions = append(result.Positions, *l)
+	}
+	sort.Slice(result.Positions, func(i, j int) bool { return result.Positions[i].PositionTitle < result.Positions[j].PositionTitle })
+	return result, nil
+}
+
+// notifyRecruitment gửi vị trí tuyển dụng mới tới RECRUITMENT_WEBHOOK_URL nếu được cấu hình.
+// Việc gửi chạy nền và lỗi chỉ được ghi log, không ảnh hưởng tới việc mở vị trí.
+func notifyRecruitment(vacancy models.Vacancy, department models.Department, position models.Position) {
+	url := config.GetEnv("RECRUITMENT_WEBHOOK_URL")
+	if url == "" {
+		return
+	}
+	payload, err := json.Marshal(gin.H{
+		"event":      "vacancy.opened",
+		"vacancy":    vacancy,
+		"department": gin.H{"id": department.ID, "name": department.Name},
+		"position":   gin.H{"id": position.ID, "title": position.Title, "level": position.Level},
+	})
+	if err != nil {
+		log.Printf("Failed to encode vacancy %d for recruitment: %v", vacancy.ID, err)
+		return
+	}
+	postWebhook(url, payload, fmt.Sprintf("vacancy %d", vacancy.ID))
+}
+
+// GetDepartmentHeadcount godoc
+// @Summary Get a department's headcount
+// @Description Compare approved headcount per position with the employees holding that position whose primary department is this one, with open slots and open vacancies
+// @Tags Department
+// @Produce json
+// @Param department_id path int true "Department ID"
+// @Success 200 {object} DepartmentHeadcount
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/departments/{department_id}/headcount [get]
+func GetDepartmentHeadcount(c *gin.Context) {
+	var department models.Department
+	if err := config.GetDB().Where("id = ?", c.Param("department_id")).First(&department).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Department not found"})
+		return
+	}
+
+	result, err := departmentHeadcount(department)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to calculate headcount"})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// SetHeadcountPlan godoc
+// @Summary Set approved headcount
+// @Description An HR manager sets the approved headcount of a position in a department; 0 removes the plan
+// @Tags Department
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body HeadcountPlanInput true "Headcount plan"
+// @Success 200 {object} DepartmentHeadcount
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/departments/{id}/headcount [put]
+func SetHeadcountPlan(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can approve headcount"})
+		return
+	}
+
+	var department models.Department
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&department).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Department not found"})
+		return
+	}
+
+	var input HeadcountPlanInput
+	if err := c.ShouldBindJSON(&input); err != nil || input.PlannedCount < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	var position models.Position
+	if err := config.GetDB().First(&position, input.PositionID).Error; err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Position not found"})
+		return
+	}
+
+	var plan models.HeadcountPlan
+	err := config.GetDB().Where("department_id = ? AND position_id = ?", department.ID, position.ID).First(&plan).Error
+	switch {
+	case err == nil && input.PlannedCount == 0:
+		err = config.GetDB().Delete(&plan).Error
+	case err == nil:
+		plan.PlannedCount = input.PlannedCount
+		plan.Note = input.Note
+		plan.UpdatedByID = actor.ID
+		err = config.GetDB().Save(&plan).Error
+	case errors.Is(err, gorm.ErrRecordNotFound) && input.PlannedCount == 0:
+		err = nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		plan = models.HeadcountPlan{
+			DepartmentID: department.ID,
+			PositionID:   position.ID,
+			PlannedCount: input.PlannedCount,
+			Note:         input.Note,
+			UpdatedByID:  actor.ID,
+		}
+		err = config.GetDB().Create(&plan).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to save headcount plan"})
+		return
+	}
+
+	result, err := departmentHeadcount(department)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to calculate headcount"})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetVacancies godoc
+// @Summary Get vacancies
+// @Description List vacancies, newest first. Recruitment tools can poll status=open to pick up new openings.
+// @Tags Vacancy
+// @Produce json
+// @Param status query string false "Status (open, filled, cancelled)"
+// @Param department_id query int false "Department ID"
+// @Param position_id query int false "Position ID"
+// @Success 200 {array} models.Vacancy
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/vacancies [get]
+func GetVacancies(c *gin.Context) {
+	query := config.GetDB().Model(&models.Vacancy{})
+	if status := c.Query("status"); status != "" {
+		if status != models.VacancyOpen && status != models.VacancyFilled && status != models.VacancyCancelled {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid status. Must be open, filled or cancelled"})
+			return
+		}
+		query = query.Where("status = ?", status)
+	}
+	for _, param := range []string{"department_id", "position_id"} {
+		if value := c.Query(param); value != "" {
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid " + param})
+				return
+			}
+			query = query.Where(param+" = ?", id)
+		}
+	}
+
+	var vacancies []models.Vacancy
+	if err := query.Order("created_at DESC, id DESC").Find(&vacancies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch vacancies"})
+		return
+	}
+	c.JSON(http.StatusOK, vacancies)
+}
+
+// OpenVacancy godoc
+// @Summary Open a vacancy
+// @Description An HR manager opens a vacancy against an open headcount slot and, when RECRUITMENT_WEBHOOK_URL is set, sends it to the recruitment pipeline
+// @Tags Vacancy
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body VacancyInput true "Vacancy"
+// @Success 201 {object} models.Vacancy
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/vacancies [post]
+func OpenVacancy(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can open vacancies"})
+		return
+	}
+
+	var input VacancyInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	var department models.Department
+	if err := config.GetDB().First(&department, input.DepartmentID).Error; err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Department not found"})
+		return
+	}
+	var position models.Position
+	if err := config.GetDB().First(&position, input.PositionID).Error; err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Position not found"})
+		return
+	}
+
+	vacancy := models.Vacancy{
+		DepartmentID:         department.ID,
+		PositionID:           position.ID,
+		Title:                strings.TrimSpace(input.Title),
+		Description:          input.Description,
+		Status:               models.VacancyOpen,
+		RecruitmentReference: input.RecruitmentReference,
+		TargetStartDate:      input.TargetStartDate,
+		OpenedByID:           actor.ID,
+	}
+	if vacancy.Title == "" {
+		vacancy.Title = position.Title
+	}
+
+	// Mỗi vị trí tuyển dụng phải ứng với một chỗ trống trong định biên đã duyệt.
+	// Khóa dòng định biên để hai yêu cầu đồng thời không cùng lấy một chỗ trống.
+	err := config.GetDB().Transaction(func(tx *gorm.DB) error {
+		var plan models.HeadcountPlan
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("department_id = ? AND position_id = ?", department.ID, position.ID).First(&plan).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errNoHeadcount
+			}
+			return err
+		}
+		filled, err := filledCounts(tx, department.ID)
+		if err != nil {
+			return err
+		}
+		open, err := openVacancyCounts(tx, department.ID)
+		if err != nil {
+			return err
+		}
+		if filled[position.ID]+open[position.ID] >= plan.PlannedCount {
+			return errNoHeadcount
+		}
+		return tx.Create(&vacancy).Error
+	})
+	if errors.Is(err, errNoHeadcount) {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to open vacancy"})
+		return
+	}
+
+	notifyRecruitment(vacancy, department, position)
+	c.JSON(http.StatusCreated, vacancy)
+}
+
+// FillVacancy godoc
+// @Summary Fill a vacancy
+// @Description An HR manager closes an open vacancy with the hired employee, who moves to the vacancy's department as their primary department and is given its position
+// @Tags Vacancy
+// @Accept json
+// @Produce json
+// @Param id path int true "Vacancy ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body FillVacancyRequest true "Hired employee"
+// @Success 200 {object} models.Vacancy
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/vacancies/{id}/fill [post]
+func FillVacancy(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can fill vacancies"})
+		return
+	}
+
+	var vacancy models.Vacancy
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&vacancy).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Vacancy not found"})
+		return
+	}
+	if vacancy.Status != models.VacancyOpen {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: fmt.Sprintf("Vacancy is already %s", vacancy.Status)})
+		return
+	}
+
+	var request FillVacancyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	var employee models.Employee
+	if err := config.GetDB().First(&employee, request.EmployeeID).Error; err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	now := time.Now()
+	err := config.GetDB().Transaction(func(tx *gorm.DB) error {
+		// Chỉ một yêu cầu đóng được vị trí tuyển khi có nhiều yêu cầu cùng lúc
+		result := tx.Model(&models.Vacancy{}).Where("id = ? AND status = ?", vacancy.ID, models.VacancyOpen).
+			Updates(map[string]interface{}{
+				"status":                models.VacancyFilled,
+				"filled_by_employee_id": employee.ID,
+				"closed_at":             now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return &membershipError{http.StatusConflict, "Vacancy is no longer open"}
+		}
+
+		// Phòng ban của vị trí tuyển trở thành phòng ban chính để nhân viên chiếm đúng định biên
+		if err := fillDepartment(tx, employee.ID, vacancy.DepartmentID, now); err != nil {
+			return err
+		}
+		// Nhân viên giữ chức vụ từ hôm nay, trừ khi đã có từ trước
+		change := membershipChange{table: "employee_positions", column: "position_id", employeeID: employee.ID, toID: vacancy.PositionID, effective: now}
+		var count int64
+		if err := activeOn(tx.Table(change.table), change.table, now).
+			Where("employee_id = ? AND position_id = ?", employee.ID, change.toID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		return change.apply(tx)
+	})
+	if err != nil {
+		writeMembershipError(c, err, "Failed to fill vacancy")
+		return
+	}
+
+	vacancy.Status = models.VacancyFilled
+	vacancy.FilledByEmployeeID = &employee.ID
+	vacancy.ClosedAt = &now
+	c.JSON(http.StatusOK, vacancy)
+}
+
+// fillDepartment đưa phòng ban departmentID thành phòng ban chính của nhân viên từ ngày hiệu lực.
+// Nhân viên được chuyển từ phòng ban chính cũ sang và giữ tỉ lệ phân bổ của nó,
+// bản ghi phụ sẵn có ở phòng ban này được thay bằng bản ghi chính.
+func fillDepartment(tx *gorm.DB, employeeID, departmentID uint, effective time.Time) error {
+	change := membershipChange{table: "employee_departments", column: "department_id", employeeID: employeeID, toID: departmentID, effective: effective}
+	current, err := loadMemberships(tx, change.table, change.column, employeeID, &effective)
+	if err != nil {
+		return err
+	}
+	day := effective.Format("2006-01-02")
+	for _, row := range current {
+		switch {
+		case row.ReferenceID == departmentID && row.IsPrimary:
+			return nil
+		case row.ReferenceID == departmentID:
+			if err := tx.Exec("DELETE FROM employee_departments WHERE employee_id = ? AND department_id = ? AND start_date = ?",
+				employeeID, departmentID, day).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("UPDATE employee_departments SET end_date = ? WHERE employee_id = ? AND department_id = ? "+
+				"AND (start_date IS NULL OR start_date < ?) AND (end_date IS NULL OR end_date >= ?)",
+				effective.AddDate(0, 0, -1).Format("2006-01-02"), employeeID, departmentID, day, day).Error; err != nil {
+				return err
+			}
+		case row.IsPrimary:
+			primaryID := row.ReferenceID
+			change.fromID = &primaryID
+		}
+	}
+	isPrimary := true
+	change.isPrimary = &isPrimary
+	return change.apply(tx)
+}
+
+// CancelVacancy godoc
+// @Summary Cancel a vacancy
+// @Description An HR manager cancels an open vacancy, releasing its headcount slot
+// @Tags Vacancy
+// @Produce json
+// @Param id path int true "Vacancy ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {object} models.Vacancy
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/vacancies/{id}/cancel [post]
+func CancelVacancy(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can cancel vacancies"})
+		return
+	}
+
+	var vacancy models.Vacancy
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&vacancy).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Vacancy not found"})
+		return
+	}
+	if vacancy.Status != models.VacancyOpen {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: fmt.Sprintf("Vacancy is already %s", vacancy.Status)})
+		return
+	}
+
+	now := time.Now()
+	if err := config.GetDB().Model(&vacancy).Updates(map[string]interface{}{
+		"status":    models.VacancyCancelled,
+		"closed_at": now,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to cancel vacancy"})
+		return
+	}
+	vacancy.Status = models.VacancyCancelled
+	vacancy.ClosedAt = &now
+	c.JSON(http.StatusOK, vacancy)
+}
@@ -0,0 +1,356 @@
+package controllers
+
+import (
+	"employee-management/calendar"
+	"employee-management/config"
+	"employee-management/models"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// holidaySet lấy các ngày nghỉ lễ trong khoảng [from, to], khóa là "YYYY-MM-DD"
+func holidaySet(from, to time.Time) (map[string]models.Holiday, error) {
+	var holidays []models.Holiday
+	if err := config.GetDB().
+		Where("date BETWEEN ? AND ?", calendar.DateKey(from), calendar.DateKey(to)).
+		Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]models.Holiday, len(holidays))
+	for _, h := range holidays {
+		set[calendar.DateKey(h.Date.Time)] = h
+	}
+	return set, nil
+}
+
+// countBusinessDays đếm số ngày làm việc trong [from, to], trừ ngày nghỉ hằng tuần và ngày lễ
+func countBusinessDays(from, to time.Time) (int, []models.Holiday, error) {
+	schedule, err := workSchedule()
+	if err != nil {
+		return 0, nil, err
+	}
+	holidays, err := holidaySet(from, to)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	days := 0
+	matched := []models.Holiday{}
+	for day := dateOnly(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if holiday, ok := holidays[calendar.DateKey(day)]; ok {
+			matched = append(matched, holiday)
+			continue
+		}
+		if schedule.IsWorkday(day) {
+			days++
+		}
+	}
+	return days, matched, nil
+}
+
+// parseDateRange đọc tham số from/to dạng YYYY-MM-DD từ query string
+func parseDateRange(c *gin.Context) (time.Time, time.Time, bool) {
+	from, errFrom := time.ParseInLocation("2006-01-02", c.Query("from"), time.Local)
+	to, errTo := time.ParseInLocation("2006-01-02", c.Query("to"), time.Local)
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "from and to are required in YYYY-MM-DD format"})
+		return from, to, false
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "to must not be before from"})
+		return from, to, false
+	}
+	return from, to, true
+}
+
+// GetHolidays godoc
+// @Summary Get holidays
+// @Description List statutory, substitute and company holidays of a year
+// @Tags Holiday
+// @Produce json
+// @Param year query int false "Year, defaults to the current year"
+// @Success 200 {array} models.Holiday
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/holidays [get]
+func GetHolidays(c *gin.Context) {
+	year := time.Now().Year()
+	if value := c.Query("year"); value != "" {
+		var err error
+		if year, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid year format"})
+			return
+		}
+	}
+
+	var holidays []models.Holiday
+	if err := config.GetDB().Where("year = ?", year).Order("date").Find(&holidays).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch holidays"})
+		return
+	}
+	c.JSON(http.StatusOK, holidays)
+}
+
+// GenerateHolidays godoc
+// @Summary Generate statutory holidays for a year
+// @Description Compute Vietnamese statutory holidays (including Tết and Hùng Kings day from the lunar calendar) and substitute days, replacing previously generated ones for that year
+// @Tags Holiday
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param year query int true "Year"
+// @Success 200 {array} models.Holiday
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/holidays/generate [post]
+func GenerateHolidays(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage holidays"})
+		return
+	}
+
+	year, err := strconv.Atoi(c.Query("year"))
+	if err != nil || year < 1900 || year > 2199 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "A valid year is required"})
+		return
+	}
+
+	schedule, err := workSchedule()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Invalid work schedule configuration: " + err.Error()})
+		return
+	}
+	statutory, err := calendar.StatutoryHolidays(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to compute holidays"})
+		return
+	}
+
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		// Sinh lại ngày lễ và nghỉ bù, giữ nguyên ngày nghỉ riêng của công ty
+		if err := tx.Where("year = ? AND type IN ?", year, []string{models.HolidayStatutory, models.HolidaySubstitute}).
+			Delete(&models.Holiday{}).Error; err != nil {
+			return err
+		}
+
+		var company []models.Holiday
+		if err := tx.Where("year = ? AND type = ?", year, models.HolidayCompany).Find(&company).Error; err != nil {
+			return err
+		}
+		occupied := map[string]bool{}
+		for _, h := range company {
+			occupied[calendar.DateKey(h.Date.Time)] = true
+		}
+
+		for _, h := range calendar.WithSubstitutes(statutory, schedule.IsWorkday, occupied) {
+			key := calendar.DateKey(h.Date)
+			if occupied[key] {
+				continue
+			}
+			occupied[key] = true
+
+			holidayType := models.HolidayStatutory
+			if h.Substitute {
+				holidayType = models.HolidaySubstitute
+			}
+			if err := tx.Create(&models.Holiday{
+				Date: models.CustomTime{Time: h.Date},
+				Year: year,
+				Name: h.Name,
+				Type: holidayType,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to save holidays"})
+		return
+	}
+
+	var holidays []models.Holiday
+	if err := config.GetDB().Where("year = ?", year).Order("date").Find(&holidays).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch holidays"})
+		return
+	}
+	c.JSON(http.StatusOK, holidays)
+}
+
+// CreateHoliday godoc
+// @Summary Add a company day off
+// @Description Add a company-specific paid day off to the holiday calendar
+// @Tags Holiday
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param holiday body models.Holiday true "Date and name"
+// @Success 201 {object} models.Holiday
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/holidays [post]
+func CreateHoliday(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage holidays"})
+		return
+	}
+
+	var holiday models.Holiday
+	if err := c.ShouldBindJSON(&holiday); err != nil || holiday.Date.IsZero() || holiday.Name == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "date and name are required"})
+		return
+	}
+
+	var existing models.Holiday
+	if err := config.GetDB().Where("date = ?", calendar.DateKey(holiday.Date.Time)).First(&existing).Error; err == nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "This date is already a holiday: " + existing.Name})
+		return
+	}
+
+	holiday.ID = 0
+	holiday.Year = holiday.Date.Year()
+	holiday.Type = models.HolidayCompany
+	if err := config.GetDB().Create(&holiday).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create holiday"})
+		return
+	}
+	c.JSON(http.StatusCreated, holiday)
+}
+
+// DeleteHoliday godoc
+// @Summary Delete a holiday
+// @Description Remove a holiday from the calendar
+// @Tags Holiday
+// @Produce json
+// @Param id path int true "Holiday ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {object} ResponseMessage
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/holidays/{id} [delete]
+func DeleteHoliday(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage holidays"})
+		return
+	}
+
+	var holiday models.Holiday
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&holiday).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Holiday not found"})
+		return
+	}
+	if err := config.GetDB().Delete(&holiday).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to delete holiday"})
+		return
+	}
+	c.JSON(http.StatusOK, ResponseMessage{Message: "Holiday deleted successfully"})
+}
+
+// BusinessDaysResponse là số ngày làm việc giữa hai ngày
+type BusinessDaysResponse struct {
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	BusinessDays int              `json:"business_days"`
+	Holidays     []models.Holiday `json:"holidays"`
+}
+
+// GetBusinessDays godoc
+// @Summary Count business days between two dates
+// @Description Count working days in [from, to], excluding weekly days off and holidays
+// @Tags Holiday
+// @Produce json
+// @Param from query string true "From date (YYYY-MM-DD)"
+// @Param to query string true "To date (YYYY-MM-DD)"
+// @Success 200 {object} BusinessDaysResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/calendar/business-days [get]
+func GetBusinessDays(c *gin.Context) {
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+
+	days, holidays, err := countBusinessDays(from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to count business days"})
+		return
+	}
+
+	c.JSON(http.StatusOK, BusinessDaysResponse{
+		From:         calendar.DateKey(from),
+		To:           calendar.DateKey(to),
+		BusinessDays: days,
+		Holidays:     holidays,
+	})
+}
+
+// LunarConversion là kết quả chuyển đổi giữa âm lịch và dương lịch
+type LunarConversion struct {
+	Solar string             `json:"solar"`
+	Lunar calendar.LunarDate `json:"lunar"`
+}
+
+// ConvertLunarDate godoc
+// @Summary Convert between solar and lunar dates
+// @Description Pass solar=YYYY-MM-DD to get the lunar date, or lunar_day, lunar_month, lunar_year (and leap) to get the solar date
+// @Tags Holiday
+// @Produce json
+// @Param solar query string false "Solar date (YYYY-MM-DD)"
+// @Param lunar_day query int false "Lunar day"
+// @Param lunar_month query int false "Lunar month"
+// @Param lunar_year query int false "Lunar year"
+// @Param leap query bool false "Leap month"
+// @Success 200 {object} LunarConversion
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/calendar/lunar [get]
+func ConvertLunarDate(c *gin.Context) {
+	if solar := c.Query("solar"); solar != "" {
+		date, err := time.ParseInLocation("2006-01-02", solar, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid solar date, expected YYYY-MM-DD"})
+			return
+		}
+		c.JSON(http.StatusOK, LunarConversion{Solar: calendar.DateKey(date), Lunar: calendar.SolarToLunar(date)})
+		return
+	}
+
+	day, errDay := strconv.Atoi(c.Query("lunar_day"))
+	month, errMonth := strconv.Atoi(c.Query("lunar_month"))
+	year, errYear := strconv.Atoi(c.Query("lunar_year"))
+	if errDay != nil || errMonth != nil || errYear != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Provide either solar or lunar_day, lunar_month and lunar_year"})
+		return
+	}
+
+	lunar := calendar.LunarDate{Day: day, Month: month, Year: year, Leap: c.Query("leap") == "true"}
+	date, err := calendar.LunarToSolar(lunar)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, LunarConversion{Solar: calendar.DateKey(date), Lunar: lunar})
+}
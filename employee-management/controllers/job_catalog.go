@@ -0,0 +1,599 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"employee-management/payroll"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobFamilyInput là dữ liệu tạo hoặc cập nhật nhóm nghề
+type JobFamilyInput struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// PayGradeInput là dữ liệu tạo hoặc cập nhật bậc lương
+type PayGradeInput struct {
+	Code      string `json:"code" binding:"required"`
+	Name      string `json:"name"`
+	MinSalary int    `json:"min_salary"`
+	MidSalary int    `json:"mid_salary"`
+	MaxSalary int    `json:"max_salary"`
+}
+
+// EmployeeCompaRatio là lương cơ bản của một nhân viên so với khung lương của chức vụ
+type EmployeeCompaRatio struct {
+	EmployeeID    uint    `json:"employee_id"`
+	EmployeeName  string  `json:"employee_name"`
+	PositionTitle string  `json:"position_title"`
+	PayGradeCode  string  `json:"pay_grade_code"`
+	BasicSalary   int     `json:"basic_salary"`
+	MinSalary     int     `json:"min_salary"`
+	MidSalary     int     `json:"mid_salary"`
+	MaxSalary     int     `json:"max_salary"`
+	CompaRatio    float64 `json:"compa_ratio"`
+	BandPosition  string  `json:"band_position"` // below, within hoặc above
+}
+
+// DepartmentCompaRatio tổng hợp compa-ratio của một phòng ban
+type DepartmentCompaRatio struct {
+	DepartmentID      uint                 `json:"department_id"`
+	DepartmentName    string               `json:"department_name"`
+	Headcount         int                  `json:"headcount"` // Số nhân viên có khung lương
+	AverageCompaRatio float64              `json:"average_compa_ratio"`
+	Below             int                  `json:"below"`
+	Within            int                  `json:"within"`
+	Above             int                  `json:"above"`
+	Unbanded          int                  `json:"unbanded"` // Nhân viên có lương nhưng chức vụ chưa gắn bậc lương
+	Employees         []EmployeeCompaRatio `json:"employees"`
+}
+
+// employeeBand là khung lương áp dụng cho một nhân viên cùng chức vụ quy định khung đó
+type employeeBand struct {
+	PositionTitle string
+	PayGradeCode  string
+	Band          payroll.Band
+}
+
+// employeeBands tìm khung lương của từng nhân viên. Nhân viên giữ nhiều chức vụ có bậc lương
+// dùng khung của chức vụ chính, sau đó là chức vụ cấp cao nhất, cùng cấp thì chức vụ có ID nhỏ hơn.
+func employeeBands(employeeIDs []uint) (map[uint]employeeBand, error) {
+	var rows []struct {
+		EmployeeID uint
+		Title      string
+		Code       string
+		MinSalary  int
+		MidSalary  int
+		MaxSalary  int
+	}
+	if err := activePositions(config.GetDB().Table("employee_positions")).
+		Select("employee_positions.employee_id, positions.title, pay_grades.code, pay_grades.min_salary, pay_grades.mid_salary, pay_grades.max_salary").
+		Joins("JOIN positions ON positions.id = employee_positions.position_id").
+		Joins("JOIN pay_grades ON pay_grades.id = positions.pay_grade_id").
+		Where("employee_positions.employee_id IN ?", employeeIDs).
+		Order("employee_positions.is_primary DESC, positions.level DESC, positions.id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	bands := map[uint]employeeBand{}
+	for _, row := range rows {
+		if _, ok := bands[row.EmployeeID]; ok {
+			continue
+		}
+		bands[row.EmployeeID] = employeeBand{
+			PositionTitle: row.Title,
+			PayGradeCode:  row.Code,
+			Band:          payroll.Band{Min: row.MinSalary, Mid: row.MidSalary, Max: row.MaxSalary},
+		}
+	}
+	return bands, nil
+}
+
+// salaryBandWarnings cảnh báo khi lương cơ bản nằm ngoài khung lương của chức vụ nhân viên.
+// Nhân viên chưa có chức vụ gắn bậc lương không bị cảnh báo.
+func salaryBandWarnings(employeeID uint, basicSalary int) ([]string, error) {
+	bands, err := employeeBands([]uint{employeeID})
+	if err != nil {
+		return nil, err
+	}
+	band, ok := bands[employeeID]
+	if !ok {
+		return nil, nil
+	}
+	switch band.Band.Position(basicSalary) {
+	case payroll.BandBelow:
+		return []string{fmt.Sprintf("Basic salary %d is below the minimum %d of pay grade %s (%s)", basicSalary, band.Band.Min, band.PayGradeCode, band.PositionTitle)}, nil
+	case payroll.BandAbove:
+		return []string{fmt.Sprintf("Basic salary %d is above the maximum %d of pay grade %s (%s)", basicSalary, band.Band.Max, band.PayGradeCode, band.PositionTitle)}, nil
+	}
+	return nil, nil
+}
+
+// writeBandWarnings thêm header Warning khi lương cơ bản nằm ngoài khung lương.
+// Lỗi khi tra khung lương không chặn thao tác chính.
+func writeBandWarnings(c *gin.Context, employeeID uint, basicSalary int) {
+	warnings, err := salaryBandWarnings(employeeID, basicSalary)
+	if err != nil {
+		return
+	}
+	for _, warning := range warnings {
+		c.Writer.Header().Add("Warning", fmt.Sprintf(`199 - "%s"`, warning))
+	}
+}
+
+// validatePositionCatalog kiểm tra nhóm nghề, cấp bậc và bậc lương của chức vụ
+func validatePositionCatalog(position models.Position) error {
+	if position.Level < 0 {
+		return fmt.Errorf("level must not be negative")
+	}
+	if position.JobFamilyID != nil {
+		var family models.JobFamily
+		if err := config.GetDB().First(&family, *position.JobFamilyID).Error; err != nil {
+			return fmt.Errorf("Job family not found")
+		}
+	}
+	if position.PayGradeID != nil {
+		var grade models.PayGrade
+		if err := config.GetDB().First(&grade, *position.PayGradeID).Error; err != nil {
+			return fmt.Errorf("Pay grade not found")
+		}
+	}
+	return nil
+}
+
+// GetJobFamilies godoc
+// @Summary Get job families
+// @Description List the job families positions are grouped into
+// @Tags Position
+// @Produce json
+// @Success 200 {array} models.JobFamily
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/job-families [get]
+func GetJobFamilies(c *gin.Context) {
+	var families []models.JobFamily
+	if err := config.GetDB().Order("name").Find(&families).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch job families"})
+		return
+	}
+	c.JSON(http.StatusOK, families)
+}
+
+// CreateJobFamily godoc
+// @Summary Create a job family
+// @Description An HR manager adds a job family
+// @Tags Position
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body JobFamilyInput true "Job family"
+// @Success 201 {object} models.JobFamily
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/job-families [post]
+func CreateJobFamily(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage the position catalog"})
+		return
+	}
+
+	var input JobFamilyInput
+	if err := c.ShouldBindJSON(&input); err != nil || strings.TrimSpace(input.Name) == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	family := models.JobFamily{Name: strings.TrimSpace(input.Name), Description: input.Description}
+	var existing models.JobFamily
+	if err := config.GetDB().Where("name = ?", family.Name).First(&existing).Error; err == nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Job family already exists"})
+		return
+	}
+	if err := config.GetDB().Create(&family).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create job family"})
+		return
+	}
+	c.JSON(http.StatusCreated, family)
+}
+
+// UpdateJobFamily godoc
+// @Summary Update a job family
+// @Description An HR manager renames or describes a job family
+// @Tags Position
+// @Accept json
+// @Produce json
+// @Param id path int true "Job family ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body JobFamilyInput true "Job family"
+// @Success 200 {object} models.JobFamily
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/job-families/{id} [put]
+func UpdateJobFamily(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage the position catalog"})
+		return
+	}
+
+	var family models.JobFamily
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&family).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Job family not found"})
+		return
+	}
+
+	var input JobFamilyInput
+	if err := c.ShouldBindJSON(&input); err != nil || strings.TrimSpace(input.Name) == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	name := strings.TrimSpace(input.Name)
+	var existing models.JobFamily
+	if err := config.GetDB().Where("name = ? AND id <> ?", name, family.ID).First(&existing).Error; err == nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Job family already exists"})
+		return
+	}
+
+	family.Name = name
+	family.Description = input.Description
+	if err := config.GetDB().Save(&family).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update job family"})
+		return
+	}
+	c.JSON(http.StatusOK, family)
+}
+
+// DeleteJobFamily godoc
+// @Summary Delete a job family
+// @Description An HR manager removes a job family that no position belongs to
+// @Tags Position
+// @Produce json
+// @Param id path int true "Job family ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {object} ResponseMessage
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/job-families/{id} [delete]
+func DeleteJobFamily(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage the position catalog"})
+		return
+	}
+
+	var family models.JobFamily
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&family).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Job family not found"})
+		return
+	}
+	var positions int64
+	if err := config.GetDB().Model(&models.Position{}).Where("job_family_id = ?", family.ID).Count(&positions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check positions in job family"})
+		return
+	}
+	if positions > 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Cannot delete job family because positions belong to it"})
+		return
+	}
+
+	if err := config.GetDB().Delete(&family).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to delete job family"})
+		return
+	}
+	c.JSON(http.StatusOK, ResponseMessage{Message: "Job family deleted successfully"})
+}
+
+// GetPayGrades godoc
+// @Summary Get pay grades
+// @Description List pay grades with their min/mid/max salary bands
+// @Tags Position
+// @Produce json
+// @Success 200 {array} models.PayGrade
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/pay-grades [get]
+func GetPayGrades(c *gin.Context) {
+	var grades []models.PayGrade
+	if err := config.GetDB().Order("min_salary, code").Find(&grades).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch pay grades"})
+		return
+	}
+	c.JSON(http.StatusOK, grades)
+}
+
+// bindPayGrade đọc và kiểm tra dữ liệu bậc lương.
+// Nếu không hợp lệ, hàm tự trả lỗi về client và trả về false.
+func bindPayGrade(c *gin.Context, grade *models.PayGrade) bool {
+	var input PayGradeInput
+	if err := c.ShouldBindJSON(&input); err != nil || strings.TrimSpace(input.Code) == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return false
+	}
+	band := payroll.Band{Min: input.MinSalary, Mid: input.MidSalary, Max: input.MaxSalary}
+	if err := band.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return false
+	}
+
+	grade.Code = strings.TrimSpace(input.Code)
+	grade.Name = input.Name
+	grade.MinSalary, grade.MidSalary, grade.MaxSalary = band.Min, band.Mid, band.Max
+	var existing models.PayGrade
+	if err := config.GetDB().Where("code = ? AND id <> ?", grade.Code, grade.ID).First(&existing).Error; err == nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Pay grade already exists"})
+		return false
+	}
+	return true
+}
+
+// CreatePayGrade godoc
+// @Summary Create a pay grade
+// @Description An HR manager adds a pay grade with a min/mid/max salary band
+// @Tags Position
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body PayGradeInput true "Pay grade"
+// @Success 201 {object} models.PayGrade
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/pay-grades [post]
+func CreatePayGrade(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage the position catalog"})
+		return
+	}
+
+	var grade models.PayGrade
+	if !bindPayGrade(c, &grade) {
+		return
+	}
+	if err := config.GetDB().Create(&grade).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create pay grade"})
+		return
+	}
+	c.JSON(http.StatusCreated, grade)
+}
+
+// UpdatePayGrade godoc
+// @Summary Update a pay grade
+// @Description An HR manager changes a pay grade's salary band; existing salaries are not changed
+// @Tags Position
+// @Accept json
+// @Produce json
+// @Param id path int true "Pay grade ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body PayGradeInput true "Pay grade"
+// @Success 200 {object} models.PayGrade
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/pay-grades/{id} [put]
+func UpdatePayGrade(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage the position catalog"})
+		return
+	}
+
+	var grade models.PayGrade
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&grade).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Pay grade not found"})
+		return
+	}
+	if !bindPayGrade(c, &grade) {
+		return
+	}
+	if err := config.GetDB().Save(&grade).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update pay grade"})
+		return
+	}
+	c.JSON(http.StatusOK, grade)
+}
+
+// DeletePayGrade godoc
+// @Summary Delete a pay grade
+// @Description An HR manager removes a pay grade that no position uses
+// @Tags Position
+// @Produce json
+// @Param id path int true "Pay grade ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {object} ResponseMessage
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/pay-grades/{id} [delete]
+func DeletePayGrade(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage the position catalog"})
+		return
+	}
+
+	var grade models.PayGrade
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&grade).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Pay grade not found"})
+		return
+	}
+	var positions int64
+	if err := config.GetDB().Model(&models.Position{}).Where("pay_grade_id = ?", grade.ID).Count(&positions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check positions using pay grade"})
+		return
+	}
+	if positions > 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Cannot delete pay grade because positions use it"})
+		return
+	}
+
+	if err := config.GetDB().Delete(&grade).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to delete pay grade"})
+		return
+	}
+	c.JSON(http.StatusOK, ResponseMessage{Message: "Pay grade deleted successfully"})
+}
+
+// GetCompaRatioReport godoc
+// @Summary Get the compa-ratio report
+// @Description Compare each employee's basic salary on their latest regular salary in the period with the midpoint of their position's pay grade, grouped by department
+// @Tags Salary
+// @Produce json
+// @Param department_id query int false "Department ID"
+// @Param month query int false "Month"
+// @Param quarter query int false "Quarter"
+// @Param year query int false "Year"
+// @Success 200 {array} DepartmentCompaRatio
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/salaries/compa-ratio [get]
+func GetCompaRatioReport(c *gin.Context) {
+	filter, err := parseSalaryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	var departmentID uint
+	if value := c.Query("department_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid department ID"})
+			return
+		}
+		departmentID = uint(id)
+	}
+
+	// Lương cơ bản hiện hành là bảng lương chính thức của kỳ gần nhất trong bộ lọc
+	var salaries []models.Salary
+	if err := filter.apply(config.GetDB().Model(&models.Salary{})).
+		Where("salaries.type = ?", models.SalaryRegular).
+		Order("period_year DESC, period_month DESC, id DESC").
+		Find(&salaries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch salaries"})
+		return
+	}
+	latest := map[uint]models.Salary{}
+	employeeIDs := []uint{}
+	for _, salary := range salaries {
+		if _, ok := latest[salary.EmployeeID]; !ok {
+			latest[salary.EmployeeID] = salary
+			employeeIDs = append(employeeIDs, salary.EmployeeID)
+		}
+	}
+
+	bands, err := employeeBands(employeeIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch salary bands"})
+		return
+	}
+	memberships, err := membershipGroups("department", employeeIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch departments"})
+		return
+	}
+
+	groups := map[uint]*DepartmentCompaRatio{}
+	for _, employeeID := range employeeIDs {
+		departments := memberships[employeeID]
+		if len(departments) == 0 {
+			departments = []salaryGroup{{Key: "0", Label: "Không xác định"}}
+		}
+		salary := latest[employeeID]
+		band, banded := bands[employeeID]
+		for _, department := range departments {
+			id, _ := strconv.Atoi(department.Key)
+			if departmentID != 0 && uint(id) != departmentID {
+				continue
+			}
+			group, ok := groups[uint(id)]
+			if !ok {
+				group = &DepartmentCompaRatio{DepartmentID: uint(id), DepartmentName: department.Label, Employees: []EmployeeCompaRatio{}}
+				groups[uint(id)] = group
+			}
+			if !banded {
+				group.Unbanded++
+				continue
+			}
+			entry := EmployeeCompaRatio{
+				EmployeeID:    employeeID,
+				EmployeeName:  salary.EmployeeName,
+				PositionTitle: band.PositionTitle,
+				PayGradeCode:  band.PayGradeCode,
+				BasicSalary:   salary.BasicSalary,
+				MinSalary:     band.Band.Min,
+				MidSalary:     band.Band.Mid,
+				MaxSalary:     band.Band.Max,
+				CompaRatio:    band.Band.CompaRatio(salary.BasicSalary),
+				BandPosition:  band.Band.Position(salary.BasicSalary),
+			}
+			switch entry.BandPosition {
+			case payroll.BandBelow:
+				group.Below++
+			case payroll.BandAbove:
+				group.Above++
+			default:
+				group.Within++
+			}
+			group.Employees = append(group.Employees, entry)
+		}
+	}
+
+	report := make([]DepartmentCompaRatio, 0, len(groups))
+	for _, group := range groups {
+		group.Headcount = len(group.Employees)
+		if group.Headcount > 0 {
+			var sum float64
+			for _, entry := range group.Employees {
+				sum += entry.CompaRatio
+			}
+			group.AverageCompaRatio = math.Round(sum/float64(group.Headcount)*10000) / 10000
+		}
+		sort.Slice(group.Employees, func(i, j int) bool { return group.Employees[i].CompaRatio < group.Employees[j].CompaRatio })
+		report = append(report, *group)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].DepartmentName < report[j].DepartmentName })
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,624 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"employee-management/payroll"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// LeaveBalance là số dư ngày phép của nhân viên cho một loại nghỉ phép trong năm
+type LeaveBalance struct {
+	LeaveTypeID uint    `json:"leave_type_id"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Year        int     `json:"year"`
+	Tracked     bool    `json:"tracked"` // false nếu loại nghỉ phép không giới hạn số ngày
+	Entitled    float64 `json:"entitled"`
+	CarriedOver float64 `json:"carried_over"`
+	Used        float64 `json:"used"`
+	Pending     float64 `json:"pending"`
+	Available   float64 `json:"available"`
+}
+
+// employeeHireDate trả về ngày vào làm, mặc định là ngày tạo hồ sơ nhân viên
+func employeeHireDate(employee models.Employee) time.Time {
+	if !employee.HireDate.IsZero() {
+		return employee.HireDate.Time
+	}
+	return employee.CreatedAt
+}
+
+// leavePolicy chuyển cấu hình loại nghỉ phép thành chính sách tính ngày phép
+func leavePolicy(leaveType models.LeaveType) payroll.LeavePolicy {
+	return payroll.LeavePolicy{
+		AnnualDays:          leaveType.AnnualDays,
+		MonthlyAccrual:      leaveType.MonthlyAccrual,
+		SeniorityBonusDays:  leaveType.SeniorityBonusDays,
+		SeniorityEveryYears: leaveType.SeniorityEveryYears,
+		MaxCarryOverDays:    leaveType.MaxCarryOverDays,
+	}
+}
+
+// countLeaveDays đếm số ngày làm việc trong khoảng nghỉ phép, không tính ngày lễ
+func countLeaveDays(start, end time.Time, halfDay bool) (float64, error) {
+	businessDays, _, err := countBusinessDays(start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	days := float64(businessDays)
+	if halfDay && days > 0 {
+		days = 0.5
+	}
+	return days, nil
+}
+
+// leaveDaysInYear trả về số ngày nghỉ của đơn thuộc năm year.
+// Đơn kéo dài qua cuối năm chỉ tính những ngày làm việc nằm trong năm đó.
+func leaveDaysInYear(request models.LeaveRequest, year int) (float64, error) {
+	if request.StartDate.Year() == year && request.EndDate.Year() == year {
+		return request.Days, nil
+	}
+	start, end := request.StartDate.Time, request.EndDate.Time
+	if yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, start.Location()); start.Before(yearStart) {
+		start = yearStart
+	}
+	if yearEnd := time.Date(year, time.December, 31, 0, 0, 0, 0, end.Location()); end.After(yearEnd) {
+		end = yearEnd
+	}
+	if end.Before(start) {
+		return 0, nil
+	}
+	return countLeaveDays(start, end, request.HalfDay)
+}
+
+// sumLeaveDays cộng số ngày nghỉ thuộc năm year của các đơn theo trạng thái
+func sumLeaveDays(employeeID, leaveTypeID uint, year int, status string) (float64, error) {
+	yearStart, yearEnd := fmt.Sprintf("%d-01-01", year), fmt.Sprintf("%d-12-31", year)
+	requests := func() *gorm.DB {
+		return config.GetDB().Model(&models.LeaveRequest{}).
+			Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date <= ? AND end_date >= ?",
+				employeeID, leaveTypeID, status, yearEnd, yearStart)
+	}
+
+	// Đơn nằm trọn trong năm dùng số ngày đã lưu
+	var total float64
+	if err := requests().Where("start_date >= ? AND end_date <= ?", yearStart, yearEnd).
+		Select("COALESCE(SUM(days), 0)").Scan(&total).Error; err != nil {
+		return 0, err
+	}
+
+	// Đơn kéo dài qua đầu hoặc cuối năm được chia theo ngày làm việc của từng năm
+	var crossing []models.LeaveRequest
+	if err := requests().Where("(start_date < ? OR end_date > ?)", yearStart, yearEnd).Find(&crossing).Error; err != nil {
+		return 0, err
+	}
+	for _, request := range crossing {
+		days, err := leaveDaysInYear(request, year)
+		if err != nil {
+			return 0, err
+		}
+		total += days
+	}
+	return total, nil
+}
+
+// computeLeaveBalance tính số dư ngày phép của năm, gồm cả số ngày chuyển từ năm trước
+func computeLeaveBalance(employee models.Employee, leaveType models.LeaveType, year int, asOf time.Time) (LeaveBalance, error) {
+	balance := LeaveBalance{
+		LeaveTypeID: leaveType.ID,
+		Code:        leaveType.Code,
+		Name:        leaveType.Name,
+		Year:        year,
+		Tracked:     leaveType.AnnualDays > 0,
+	}
+
+	var err error
+	if balance.Used, err = sumLeaveDays(employee.ID, leaveType.ID, year, models.RequestApproved); err != nil {
+		return balance, err
+	}
+	if balance.Pending, err = sumLeaveDays(employee.ID, leaveType.ID, year, models.RequestPending); err != nil {
+		return balance, err
+	}
+	if !balance.Tracked {
+		return balance, nil
+	}
+
+	policy := leavePolicy(leaveType)
+	hireDate := employeeHireDate(employee)
+	balance.Entitled = policy.Entitlement(year, hireDate, asOf)
+
+	// Số dư cuối năm trước được chuyển sang, giới hạn bởi chính sách
+	if year > hireDate.Year() {
+		yearEnd := time.Date(year-1, time.December, 31, 0, 0, 0, 0, asOf.Location())
+		previous, err := computeLeaveBalance(employee, leaveType, year-1, yearEnd)
+		if err != nil {
+			return balance, err
+		}
+		balance.CarriedOver = policy.CarryOver(previous.Entitled + previous.CarriedOver - previous.Used)
+	}
+
+	balance.Available = balance.Entitled + balance.CarriedOver - balance.Used - balance.Pending
+	return balance, nil
+}
+
+// GetLeaveTypes godoc
+// @Summary Get leave types
+// @Description List leave types and their accrual policies
+// @Tags Leave
+// @Produce json
+// @Success 200 {array} models.LeaveType
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/leave-types [get]
+func GetLeaveTypes(c *gin.Context) {
+	var leaveTypes []models.LeaveType
+	if err := config.GetDB().Order("id").Find(&leaveTypes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch leave types"})
+		return
+	}
+	c.JSON(http.StatusOK, leaveTypes)
+}
+
+// CreateLeaveType godoc
+// @Summary Create a leave type
+// @Description Add a leave type with its accrual and carry-over policy
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param leaveType body models.LeaveType true "Leave type data"
+// @Success 201 {object} models.LeaveType
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/leave-types [post]
+func CreateLeaveType(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage leave types"})
+		return
+	}
+
+	var leaveType models.LeaveType
+	if err := c.ShouldBindJSON(&leaveType); err != nil || leaveType.Code == "" || leaveType.Name == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	var existing models.LeaveType
+	if err := config.GetDB().Where("code = ?", leaveType.Code).First(&existing).Error; err == nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Leave type with this code already exists"})
+		return
+	}
+
+	if err := config.GetDB().Create(&leaveType).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create leave type"})
+		return
+	}
+	c.JSON(http.StatusCreated, leaveType)
+}
+
+// UpdateLeaveType godoc
+// @Summary Update a leave type
+// @Description Change the name or accrual policy of a leave type
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Leave type ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param leaveType body models.LeaveType true "Leave type data"
+// @Success 200 {object} models.LeaveType
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/leave-types/{id} [put]
+func UpdateLeaveType(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can manage leave types"})
+		return
+	}
+
+	var leaveType models.LeaveType
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&leaveType).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Leave type not found"})
+		return
+	}
+
+	id := leaveType.ID
+	if err := c.ShouldBindJSON(&leaveType); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	leaveType.ID = id
+
+	if err := config.GetDB().Save(&leaveType).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update leave type"})
+		return
+	}
+	c.JSON(http.StatusOK, leaveType)
+}
+
+// LeaveRequestInput là dữ liệu nhân viên gửi khi xin nghỉ phép
+type LeaveRequestInput struct {
+	LeaveTypeID uint              `json:"leave_type_id" binding:"required"`
+	StartDate   models.CustomTime `json:"start_date" binding:"required"`
+	EndDate     models.CustomTime `json:"end_date" binding:"required"`
+	HalfDay     bool              `json:"half_day"`
+	Reason      string            `json:"reason"`
+}
+
+// checkLeaveAvailable kiểm tra đơn nghỉ phép không vượt quá số dư còn lại
+func checkLeaveAvailable(employee models.Employee, leaveType models.LeaveType, request models.LeaveRequest, excludePending bool) error {
+	if leaveType.AnnualDays <= 0 {
+		return nil
+	}
+	// Đơn kéo dài qua cuối năm được trừ vào số dư của từng năm theo số ngày thuộc năm đó
+	for year := request.StartDate.Year(); year <= request.EndDate.Year(); year++ {
+		days, err := leaveDaysInYear(request, year)
+		if err != nil {
+			return err
+		}
+		// Ngày phép cộng dần được tính đến ngày kết thúc nghỉ trong năm
+		asOf := request.EndDate.Time
+		if yearEnd := time.Date(year, time.December, 31, 0, 0, 0, 0, asOf.Location()); asOf.After(yearEnd) {
+			asOf = yearEnd
+		}
+		balance, err := computeLeaveBalance(employee, leaveType, year, asOf)
+		if err != nil {
+			return err
+		}
+		available := balance.Available
+		if excludePending {
+			// Khi duyệt, số ngày của chính đơn này đang nằm trong Pending
+			available += days
+		}
+		if days > available {
+			return fmt.Errorf("Insufficient leave balance for %d: requested %.1f days, available %.1f", year, days, available)
+		}
+	}
+	return nil
+}
+
+// CreateLeaveRequest godoc
+// @Summary Request leave
+// @Description The current employee requests leave; working days are counted against the leave balance
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body LeaveRequestInput true "Leave request"
+// @Success 201 {object} models.LeaveRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/leaves [post]
+func CreateLeaveRequest(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var input LeaveRequestInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	if input.StartDate.IsZero() || input.EndDate.IsZero() {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "start_date and end_date are required"})
+		return
+	}
+	if input.EndDate.Before(input.StartDate.Time) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "end_date must not be before start_date"})
+		return
+	}
+	if input.HalfDay && !input.EndDate.Equal(input.StartDate.Time) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Half-day leave must start and end on the same day"})
+		return
+	}
+
+	var leaveType models.LeaveType
+	if err := config.GetDB().First(&leaveType, input.LeaveTypeID).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Leave type not found"})
+		return
+	}
+
+	days, err := countLeaveDays(input.StartDate.Time, input.EndDate.Time, input.HalfDay)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to count leave days"})
+		return
+	}
+	if days == 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "The requested period contains no working days"})
+		return
+	}
+
+	// Không cho phép trùng với đơn nghỉ khác đang chờ duyệt hoặc đã duyệt
+	var overlapping int64
+	if err := config.GetDB().Model(&models.LeaveRequest{}).
+		Where("employee_id = ? AND status IN ? AND start_date <= ? AND end_date >= ?",
+			actor.ID, []string{models.RequestPending, models.RequestApproved}, input.EndDate.Time, input.StartDate.Time).
+		Count(&overlapping).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check overlapping leave"})
+		return
+	}
+	if overlapping > 0 {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Leave overlaps an existing request"})
+		return
+	}
+
+	request := models.LeaveRequest{
+		EmployeeID:  actor.ID,
+		LeaveTypeID: leaveType.ID,
+		StartDate:   input.StartDate,
+		EndDate:     input.EndDate,
+		HalfDay:     input.HalfDay,
+		Days:        days,
+		Reason:      input.Reason,
+		Status:      models.RequestPending,
+	}
+	if err := checkLeaveAvailable(actor, leaveType, request, false); err != nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := config.GetDB().Create(&request).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create leave request"})
+		return
+	}
+	request.LeaveType = leaveType
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// GetLeaveRequests godoc
+// @Summary Get leave requests
+// @Description List leave requests, optionally filtered by employee and status
+// @Tags Leave
+// @Produce json
+// @Param employee_id query int false "Employee ID"
+// @Param status query string false "Status (pending/approved/rejected/cancelled)"
+// @Success 200 {array} models.LeaveRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/leaves [get]
+func GetLeaveRequests(c *gin.Context) {
+	query := config.GetDB().Preload("LeaveType")
+
+	if employeeID := c.Query("employee_id"); employeeID != "" {
+		id, err := strconv.Atoi(employeeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+			return
+		}
+		query = query.Where("employee_id = ?", id)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var requests []models.LeaveRequest
+	if err := query.Order("start_date DESC, id DESC").Find(&requests).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch leave requests"})
+		return
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+// reviewLeaveRequest duyệt hoặc từ chối đơn nghỉ phép đang chờ
+func reviewLeaveRequest(c *gin.Context, status string) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var body SalaryApprovalRequest
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	var request models.LeaveRequest
+	if err := config.GetDB().Preload("LeaveType").Where("id = ?", c.Param("id")).First(&request).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Leave request not found"})
+		return
+	}
+	if request.Status != models.RequestPending {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Leave request is not pending"})
+		return
+	}
+	if request.EmployeeID == actor.ID {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Employees cannot review their own leave"})
+		return
+	}
+	if !canApproveFor(actor, request.EmployeeID) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can review leave requests"})
+		return
+	}
+
+	if status == models.RequestApproved {
+		var employee models.Employee
+		if err := config.GetDB().First(&employee, request.EmployeeID).Error; err != nil {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+			return
+		}
+		if err := checkLeaveAvailable(employee, request.LeaveType, request, true); err != nil {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	now := time.Now()
+	request.Status = status
+	request.ReviewedByID = &actor.ID
+	request.ReviewedAt = &now
+	request.ReviewComment = body.Comment
+	if err := config.GetDB().Omit("LeaveType").Save(&request).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update leave request"})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// ApproveLeaveRequest godoc
+// @Summary Approve a leave request
+// @Description A manager or the requester's manager in the reporting line approves a pending leave request after re-checking the balance
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Comment"
+// @Success 200 {object} models.LeaveRequest
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/leaves/{id}/approve [post]
+func ApproveLeaveRequest(c *gin.Context) {
+	reviewLeaveRequest(c, models.RequestApproved)
+}
+
+// RejectLeaveRequest godoc
+// @Summary Reject a leave request
+// @Description A manager or the requester's manager in the reporting line rejects a pending leave request
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Comment"
+// @Success 200 {object} models.LeaveRequest
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/leaves/{id}/reject [post]
+func RejectLeaveRequest(c *gin.Context) {
+	reviewLeaveRequest(c, models.RequestRejected)
+}
+
+// CancelLeaveRequest godoc
+// @Summary Cancel a leave request
+// @Description The requester cancels a pending request or an approved leave that has not started yet
+// @Tags Leave
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {object} models.LeaveRequest
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/leaves/{id}/cancel [post]
+func CancelLeaveRequest(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var request models.LeaveRequest
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&request).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Leave request not found"})
+		return
+	}
+	if request.EmployeeID != actor.ID {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only the requester can cancel a leave request"})
+		return
+	}
+
+	started := request.StartDate.Format("2006-01-02") <= time.Now().Format("2006-01-02")
+	if request.Status != models.RequestPending && !(request.Status == models.RequestApproved && !started) {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Only pending or future approved leave can be cancelled"})
+		return
+	}
+
+	request.Status = models.LeaveCancelled
+	if err := config.GetDB().Save(&request).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to cancel leave request"})
+		return
+	}
+	c.JSON(http.StatusOK, request)
+}
+
+// GetEmployeeLeaveBalances godoc
+// @Summary Get leave balances of an employee
+// @Description Entitled, carried-over, used, pending and available days per leave type for a year
+// @Tags Leave
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param year query int false "Year, defaults to the current year"
+// @Success 200 {array} LeaveBalance
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/leave-balances [get]
+func GetEmployeeLeaveBalances(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+		return
+	}
+
+	now := time.Now()
+	year := now.Year()
+	if value := c.Query("year"); value != "" {
+		if year, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid year format"})
+			return
+		}
+	}
+
+	var employee models.Employee
+	if err := config.GetDB().First(&employee, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	var leaveTypes []models.LeaveType
+	if err := config.GetDB().Order("id").Find(&leaveTypes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch leave types"})
+		return
+	}
+
+	// Năm đã qua tính đến cuối năm, năm hiện tại tính đến hôm nay
+	asOf := now
+	if year < now.Year() {
+		asOf = time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local)
+	}
+
+	balances := make([]LeaveBalance, 0, len(leaveTypes))
+	for _, leaveType := range leaveTypes {
+		balance, err := computeLeaveBalance(employee, leaveType, year, asOf)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to compute leave balance"})
+			return
+		}
+		balances = append(balances, balance)
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
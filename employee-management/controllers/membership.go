@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// EmployeeSummaryPage là một trang trong danh sách nhân viên của phòng ban hoặc chức vụ
+type EmployeeSummaryPage struct {
+	Data     []EmployeeSummary `json:"data"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"page_size"`
+	Total    int64             `json:"total"`
+}
+
+// membershipRow là một bản ghi của employee_departments hoặc employee_positions,
+// ReferenceID là ID phòng ban hoặc chức vụ
+type membershipRow struct {
+	EmployeeID        uint
+	ReferenceID       uint
+	StartDate         *time.Time
+	EndDate           *time.Time
+	IsPrimary         bool
+	AllocationPercent int
+}
+
+// activeOn giới hạn truy vấn vào các bản ghi của bảng nối table còn hiệu lực trong ngày day
+func activeOn(query *gorm.DB, table string, day time.Time) *gorm.DB {
+	date := day.Format("2006-01-02")
+	return query.Where("("+table+".start_date IS NULL OR "+table+".start_date <= ?) AND ("+table+".end_date IS NULL OR "+table+".end_date >= ?)", date, date)
+}
+
+// activeDepartments là điều kiện Preload các phòng ban hiện tại của nhân viên
+func activeDepartments(db *gorm.DB) *gorm.DB {
+	return activeOn(db, "employee_departments", time.Now())
+}
+
+// activePositions là điều kiện Preload các chức vụ hiện tại của nhân viên
+func activePositions(db *gorm.DB) *gorm.DB {
+	return activeOn(db, "employee_positions", time.Now())
+}
+
+// parseAsOf đọc ngày as_of (YYYY-MM-DD), mặc định là hôm nay.
+// Nếu không hợp lệ, hàm tự trả lỗi về client và trả về false.
+func parseAsOf(c *gin.Context) (time.Time, bool) {
+	value := c.Query("as_of")
+	if value == "" {
+		return time.Now(), true
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid as_of format. Use YYYY-MM-DD"})
+		return day, false
+	}
+	return day, true
+}
+
+// splitAllocation chia 100% cho n bản ghi, phần dư cộng vào bản ghi đầu tiên
+func splitAllocation(n int) []int {
+	shares := make([]int, n)
+	if n == 0 {
+		return shares
+	}
+	for i := range shares {
+		shares[i] = 100 / n
+	}
+	shares[0] += 100 - shares[0]*n
+	return shares
+}
+
+// loadMemberships tải các bản ghi của nhân viên trong bảng nối, chỉ lấy bản ghi hiệu lực trong ngày asOf nếu có
+func loadMemberships(db *gorm.DB, table, column string, employeeID uint, asOf *time.Time) ([]membershipRow, error) {
+	query := db.Table(table).
+		Select("employee_id, "+column+" AS reference_id, start_date, end_date, is_primary, allocation_percent").
+		Where("employee_id = ?", employeeID)
+	if asOf != nil {
+		query = activeOn(query, table, *asOf)
+	}
+	var rows []membershipRow
+	err := query.Order("start_date, " + column).Scan(&rows).Error
+	return rows, err
+}
+
+// listMembers trả về một trang nhân viên thuộc một trong ids của bảng nối table vào ngày asOf
+func listMembers(c *gin.Context, table, column string, ids []uint, asOf time.Time) {
+	page, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	members := activeOn(config.GetDB().Table(table).Select("employee_id").Where(column+" IN ?", ids), table, asOf)
+	query := config.GetDB().Model(&models.Employee{}).Where("id IN (?)", members)
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to count employees"})
+		return
+	}
+	var employees []models.Employee
+	if err := page.apply(query.Session(&gorm.Session{})).Order("name, id").Find(&employees).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch employees"})
+		return
+	}
+
+	result := EmployeeSummaryPage{Data: make([]EmployeeSummary, 0, len(employees)), Page: page.Page, PageSize: page.PageSize, Total: total}
+	for _, employee := range employees {
+		result.Data = append(result.Data, summarizeEmployee(employee))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// parseReassignTo đọc tham số reassign_to, trả về 0 nếu không có.
+// Nếu không hợp lệ, hàm tự trả lỗi về client và trả về false.
+func parseReassignTo(c *gin.Context, currentID uint) (uint, bool) {
+	value := c.Query("reassign_to")
+	if value == "" {
+		return 0, true
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid reassign_to ID"})
+		return 0, false
+	}
+	if uint(id) == currentID {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "reassign_to must differ from the record being deleted"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// reassignMembers chuyển các nhân viên đang thuộc fromID sang toID từ hôm nay, giữ cờ chính
+// và tỉ lệ phân bổ, bỏ qua nhân viên đã thuộc toID
+func reassignMembers(tx *gorm.DB, table, column string, fromID, toID uint) error {
+	today := time.Now()
+	var current []membershipRow
+	if err := activeOn(tx.Table(table), table, today).
+		Select("employee_id, is_primary, allocation_percent").
+		Where(column+" = ?", fromID).
+		Scan(&current).Error; err != nil {
+		return fmt.Errorf("failed to load members: %w", err)
+	}
+	var existing []uint
+	if err := activeOn(tx.Table(table), table, today).
+		Where(column+" = ?", toID).
+		Pluck("employee_id", &existing).Error; err != nil {
+		return fmt.Errorf("failed to load target members: %w", err)
+	}
+	skip := map[uint]bool{}
+	for _, employeeID := range existing {
+		skip[employeeID] = true
+	}
+
+	start := today.Format("2006-01-02")
+	rows := []map[string]interface{}{}
+	for _, member := range current {
+		if skip[member.EmployeeID] {
+			continue
+		}
+		skip[member.EmployeeID] = true
+		rows = append(rows, map[string]interface{}{
+			"employee_id":        member.EmployeeID,
+			column:               toID,
+			"start_date":         start,
+			"is_primary":         member.IsPrimary,
+			"allocation_percent": member.AllocationPercent,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	if err := tx.Table(table).Create(rows).Error; err != nil {
+		return fmt.Errorf("failed to move members: %w", err)
+	}
+	return nil
+}
+
+// closeMemberships kết thúc vào hôm qua các bản ghi còn hiệu lực của id trong bảng nối table, giữ lại lịch sử.
+// Bản ghi bắt đầu từ hôm nay trở đi chưa thành lịch sử nên bị xóa.
+func closeMemberships(tx *gorm.DB, table, column string, id uint) error {
+	now := time.Now()
+	today, yesterday := now.Format("2006-01-02"), now.AddDate(0, 0, -1).Format("2006-01-02")
+	if err := tx.Exec("DELETE FROM "+table+" WHERE "+column+" = ? AND start_date >= ?", id, today).Error; err != nil {
+		return fmt.Errorf("failed to remove upcoming memberships: %w", err)
+	}
+	if err := tx.Exec("UPDATE "+table+" SET end_date = ? WHERE "+column+" = ? AND (end_date IS NULL OR end_date >= ?)",
+		yesterday, id, today).Error; err != nil {
+		return fmt.Errorf("failed to close memberships: %w", err)
+	}
+	return nil
+}
+
+// countMembers đếm số nhân viên hiện thuộc id trong bảng nối table
+func countMembers(table, column string, id uint) (int64, error) {
+	var count int64
+	err := activeOn(config.GetDB().Table(table), table, time.Now()).
+		Where(column+" = ?", id).Distinct("employee_id").Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"bytes"
+	"employee-management/config"
+	"employee-management/models"
+	"employee-management/orgchart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// orgPeople tải nhân viên kèm tên chức vụ, trả về thành viên theo ID phòng ban và nhân viên theo ID
+func orgPeople() (map[uint][]orgchart.Person, map[uint]orgchart.Person, error) {
+	var employees []models.Employee
+	if err := config.GetDB().Order("name, id").Find(&employees).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var positions []struct {
+		EmployeeID uint
+		Title      string
+	}
+	if err := activePositions(config.GetDB().Table("employee_positions")).
+		Select("employee_positions.employee_id, positions.title").
+		Joins("JOIN positions ON positions.id = employee_positions.position_id").
+		Order("positions.title").
+		Scan(&positions).Error; err != nil {
+		return nil, nil, err
+	}
+	titles := map[uint][]string{}
+	for _, p := range positions {
+		titles[p.EmployeeID] = append(titles[p.EmployeeID], p.Title)
+	}
+
+	people := make(map[uint]orgchart.Person, len(employees))
+	for _, employee := range employees {
+		positions := titles[employee.ID]
+		if positions == nil {
+			positions = []string{}
+		}
+		people[employee.ID] = orgchart.Person{ID: employee.ID, Name: employee.Name, Email: employee.Email, Positions: positions}
+	}
+
+	var memberships []models.EmployeeDepartment
+	if err := activeDepartments(config.GetDB()).Find(&memberships).Error; err != nil {
+		return nil, nil, err
+	}
+	members := map[uint][]orgchart.Person{}
+	seen := map[[2]uint]bool{}
+	for _, membership := range memberships {
+		key := [2]uint{membership.DepartmentID, membership.EmployeeID}
+		person, ok := people[membership.EmployeeID]
+		if !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		members[membership.DepartmentID] = append(members[membership.DepartmentID], person)
+	}
+	return members, people, nil
+}
+
+// buildOrgChart dựng các nút sơ đồ tổ chức cho danh sách phòng ban và các phòng ban cấp dưới.
+// depth là số cấp còn được đưa vào, nhỏ hơn hoặc bằng 0 nghĩa là không giới hạn.
+func buildOrgChart(departments []models.Department, children map[uint][]models.Department,
+	members map[uint][]orgchart.Person, people map[uint]orgchart.Person, depth int, seen map[uint]bool) []orgchart.Node {
+	nodes := []orgchart.Node{}
+	for _, department := range departments {
+		if seen[department.ID] {
+			continue
+		}
+		seen[department.ID] = true
+		node := orgchart.Node{ID: department.ID, Name: department.Name, Members: members[department.ID], Children: []orgchart.Node{}}
+		if node.Members == nil {
+			node.Members = []orgchart.Person{}
+		}
+		if department.HeadID != nil {
+			if head, ok := people[*department.HeadID]; ok {
+				node.Head = &head
+			}
+		}
+		if depth != 1 {
+			node.Children = buildOrgChart(children[department.ID], children, members, people, depth-1, seen)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// GetOrgChart godoc
+// @Summary Get the organization chart
+// @Description Get the department tree with heads, members and their positions as JSON, or export it as Graphviz DOT or SVG
+// @Tags Department
+// @Produce json
+// @Produce text/vnd.graphviz
+// @Produce image/svg+xml
+// @Param root_id query int false "Root department ID, defaults to all top-level departments"
+// @Param depth query int false "Number of department levels to include, 0 for all"
+// @Param format query string false "Output format (json, dot, svg)" default(json)
+// @Success 200 {array} orgchart.Node
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/orgchart [get]
+func GetOrgChart(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "dot" && format != "svg" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid format. Must be one of json, dot, svg"})
+		return
+	}
+	depth := 0
+	if value := c.Query("depth"); value != "" {
+		var err error
+		if depth, err = strconv.Atoi(value); err != nil || depth < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "depth must be a non-negative integer"})
+			return
+		}
+	}
+
+	byID, children, err := departmentChildren()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch departments"})
+		return
+	}
+	roots := children[0]
+	if rootID := c.Query("root_id"); rootID != "" {
+		id, err := strconv.Atoi(rootID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid department ID"})
+			return
+		}
+		root, ok := byID[uint(id)]
+		if !ok {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Department not found"})
+			return
+		}
+		roots = []models.Department{root}
+	}
+
+	members, people, err := orgPeople()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch department members"})
+		return
+	}
+	chart := buildOrgChart(roots, children, members, people, depth, map[uint]bool{})
+
+	var buf bytes.Buffer
+	switch format {
+	case "dot":
+		if err := orgchart.WriteDOT(&buf, chart); err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to export organization chart"})
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="orgchart.dot"`)
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", buf.Bytes())
+	case "svg":
+		if err := orgchart.WriteSVG(&buf, chart); err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to export organization chart"})
+			return
+		}
+		c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", buf.Bytes())
+	default:
+		c.JSON(http.StatusOK, chart)
+	}
+}
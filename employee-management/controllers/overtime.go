@@ -0,0 +1,595 @@
+package controllers
+
+import (
+	"employee-management/calendar"
+	"employee-management/config"
+	"employee-management/models"
+	"employee-management/payroll"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// OvertimeRequestInput là dữ liệu nhân viên gửi khi đăng ký làm thêm giờ
+type OvertimeRequestInput struct {
+	Date      models.CustomTime `json:"date" binding:"required"`
+	StartTime string            `json:"start_time" binding:"required"`
+	EndTime   string            `json:"end_time" binding:"required"`
+	Reason    string            `json:"reason"`
+}
+
+// OvertimeRecordInput là giờ làm thêm thực tế được ghi nhận sau khi làm
+type OvertimeRecordInput struct {
+	StartTime string `json:"start_time" binding:"required"`
+	EndTime   string `json:"end_time" binding:"required"`
+}
+
+// OvertimeResponse trả về đơn làm thêm giờ kèm cảnh báo vượt giới hạn
+type OvertimeResponse struct {
+	Request  models.OvertimeRequest `json:"request"`
+	Warnings []string               `json:"warnings"`
+}
+
+// OvertimeSummary là tổng số giờ làm thêm của nhân viên trong tháng và năm
+type OvertimeSummary struct {
+	EmployeeID      uint               `json:"employee_id"`
+	Year            int                `json:"year"`
+	Month           int                `json:"month"`
+	MonthHours      float64            `json:"month_hours"`
+	YearHours       float64            `json:"year_hours"`
+	MonthlyCapHours float64            `json:"monthly_cap_hours"`
+	YearlyCapHours  float64            `json:"yearly_cap_hours"`
+	ByCategory      map[string]float64 `json:"by_category"` // Giờ đã ghi nhận trong tháng theo loại, ca đêm có hậu tố "_night"
+	Warnings        []string           `json:"warnings"`
+}
+
+// envFloat đọc số thực từ biến môi trường, dùng giá trị mặc định nếu không hợp lệ
+func envFloat(key string, fallback float64) float64 {
+	if value, err := strconv.ParseFloat(config.GetEnv(key), 64); err == nil && value >= 0 {
+		return value
+	}
+	return fallback
+}
+
+// overtimePolicy đọc chính sách làm thêm giờ từ cấu hình OVERTIME_*
+func overtimePolicy() payroll.OvertimePolicy {
+	policy := payroll.DefaultOvertimePolicy()
+	policy.Multipliers[payroll.OvertimeWeekday] = envFloat("OVERTIME_WEEKDAY_MULTIPLIER", policy.Multipliers[payroll.OvertimeWeekday])
+	policy.Multipliers[payroll.OvertimeWeekend] = envFloat("OVERTIME_WEEKEND_MULTIPLIER", policy.Multipliers[payroll.OvertimeWeekend])
+	policy.Multipliers[payroll.OvertimeHoliday] = envFloat("OVERTIME_HOLIDAY_MULTIPLIER", policy.Multipliers[payroll.OvertimeHoliday])
+	policy.NightPremium = envFloat("OVERTIME_NIGHT_PREMIUM", policy.NightPremium)
+	policy.MonthlyCapHours = envFloat("OVERTIME_MONTHLY_CAP_HOURS", policy.MonthlyCapHours)
+	policy.YearlyCapHours = envFloat("OVERTIME_YEARLY_CAP_HOURS", policy.YearlyCapHours)
+	// Giữ khung giờ đêm mặc định nếu cấu hình sai
+	_ = policy.SetNightWindow(config.GetEnvDefault("OVERTIME_NIGHT_START", "22:00"), config.GetEnvDefault("OVERTIME_NIGHT_END", "06:00"))
+	return policy
+}
+
+// standardHoursPerDay là số giờ công chuẩn một ngày, cấu hình qua PAYROLL_STANDARD_HOURS_PER_DAY
+func standardHoursPerDay() float64 {
+	if hours := envFloat("PAYROLL_STANDARD_HOURS_PER_DAY", 8); hours > 0 {
+		return hours
+	}
+	return 8
+}
+
+// overtimeCategory xác định loại ngày làm thêm: ngày lễ, ngày nghỉ hằng tuần hoặc ngày thường
+func overtimeCategory(date time.Time) (string, error) {
+	holidays, err := holidaySet(date, date)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := holidays[calendar.DateKey(date)]; ok {
+		return payroll.OvertimeHoliday, nil
+	}
+
+	schedule, err := workSchedule()
+	if err != nil {
+		return "", err
+	}
+	if !schedule.IsWorkday(date) {
+		return payroll.OvertimeWeekend, nil
+	}
+	return payroll.OvertimeWeekday, nil
+}
+
+// overtimeHours cộng số giờ làm thêm đang chờ duyệt hoặc đã duyệt trong [from, to].
+// Đơn đã ghi nhận dùng số giờ thực tế, còn lại dùng số giờ đăng ký.
+func overtimeHours(employeeID uint, from, to time.Time, excludeID uint) (float64, error) {
+	var total float64
+	err := config.GetDB().Model(&models.OvertimeRequest{}).
+		Where("employee_id = ? AND id <> ? AND status IN ? AND date BETWEEN ? AND ?",
+			employeeID, excludeID, []string{models.RequestPending, models.RequestApproved},
+			calendar.DateKey(from), calendar.DateKey(to)).
+		Select("COALESCE(SUM(CASE WHEN recorded THEN recorded_hours ELSE planned_hours END), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// overtimeWarnings kiểm tra giới hạn giờ làm thêm tháng và năm khi thêm hours giờ vào ngày date
+func overtimeWarnings(employeeID uint, date time.Time, hours float64, excludeID uint) ([]string, error) {
+	monthStart, monthEnd := models.PeriodBounds(date.Year(), int(date.Month()))
+	month, err := overtimeHours(employeeID, monthStart, monthEnd, excludeID)
+	if err != nil {
+		return nil, err
+	}
+	yearStart, _ := models.PeriodBounds(date.Year(), 1)
+	_, yearEnd := models.PeriodBounds(date.Year(), 12)
+	year, err := overtimeHours(employeeID, yearStart, yearEnd, excludeID)
+	if err != nil {
+		return nil, err
+	}
+	return overtimePolicy().CapWarnings(month+hours, year+hours), nil
+}
+
+// overtimeEarnings gom các giờ làm thêm chưa trả lương trong kỳ thành dòng thu nhập trên bảng lương
+func overtimeEarnings(employeeID uint, start, end time.Time, hourlyRate float64) ([]models.SalaryEarningLine, []uint, int, error) {
+	var entries []models.OvertimeEntry
+	if err := config.GetDB().
+		Joins("JOIN overtime_requests ON overtime_requests.id = overtime_entries.overtime_request_id").
+		Where("overtime_entries.employee_id = ? AND overtime_entries.salary_id IS NULL AND overtime_requests.status = ?",
+			employeeID, models.RequestApproved).
+		Where("overtime_entries.date BETWEEN ? AND ?", calendar.DateKey(start), calendar.DateKey(end)).
+		Order("overtime_entries.date, overtime_entries.id").
+		Find(&entries).Error; err != nil {
+		return nil, nil, 0, err
+	}
+
+	policy := overtimePolicy()
+	lines := []models.SalaryEarningLine{}
+	index := map[string]int{}
+	ids := make([]uint, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+		key := fmt.Sprintf("%s/%t", entry.Category, entry.Night)
+		i, ok := index[key]
+		if !ok {
+			description := "Overtime " + entry.Category
+			if entry.Night {
+				description += " (night)"
+			}
+			lines = append(lines, models.SalaryEarningLine{
+				Type:        models.EarningOvertime,
+				Category:    entry.Category,
+				Night:       entry.Night,
+				Multiplier:  policy.Multiplier(entry.Category, entry.Night),
+				Description: description,
+			})
+			i = len(lines) - 1
+			index[key] = i
+		}
+		lines[i].Hours += entry.Hours
+	}
+
+	total := 0
+	for i := range lines {
+		lines[i].Amount = policy.Pay(hourlyRate, lines[i].Category, lines[i].Night, lines[i].Hours)
+		total += lines[i].Amount
+	}
+	return lines, ids, total, nil
+}
+
+// GetOvertimeRequests godoc
+// @Summary Get overtime requests
+// @Description List overtime requests, optionally filtered by employee, status and month
+// @Tags Overtime
+// @Produce json
+// @Param employee_id query int false "Employee ID"
+// @Param status query string false "Status (pending/approved/rejected)"
+// @Param year query int false "Year"
+// @Param month query int false "Month (requires year)"
+// @Success 200 {array} models.OvertimeRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/overtime [get]
+func GetOvertimeRequests(c *gin.Context) {
+	query := config.GetDB().Preload("Entries")
+
+	if employeeID := c.Query("employee_id"); employeeID != "" {
+		id, err := strconv.Atoi(employeeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+			return
+		}
+		query = query.Where("employee_id = ?", id)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if yearValue := c.Query("year"); yearValue != "" {
+		year, err := strconv.Atoi(yearValue)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid year format"})
+			return
+		}
+		query = query.Where("EXTRACT(YEAR FROM date) = ?", year)
+		if monthValue := c.Query("month"); monthValue != "" {
+			month, err := strconv.Atoi(monthValue)
+			if err != nil || month < 1 || month > 12 {
+				c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid month format"})
+				return
+			}
+			query = query.Where("EXTRACT(MONTH FROM date) = ?", month)
+		}
+	}
+
+	var requests []models.OvertimeRequest
+	if err := query.Order("date DESC, id DESC").Find(&requests).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch overtime requests"})
+		return
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+// CreateOvertimeRequest godoc
+// @Summary Request overtime
+// @Description The current employee asks for pre-approval of overtime; warnings are returned when the monthly or yearly cap would be exceeded
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body OvertimeRequestInput true "Overtime request"
+// @Success 201 {object} OvertimeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/overtime [post]
+func CreateOvertimeRequest(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var input OvertimeRequestInput
+	if err := c.ShouldBindJSON(&input); err != nil || input.Date.IsZero() {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	day, night, err := overtimePolicy().SplitHours(input.StartTime, input.EndTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	hours := day + night
+
+	var existing int64
+	if err := config.GetDB().Model(&models.OvertimeRequest{}).
+		Where("employee_id = ? AND date = ? AND status IN ?",
+			actor.ID, calendar.DateKey(input.Date.Time), []string{models.RequestPending, models.RequestApproved}).
+		Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check existing overtime"})
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "An overtime request already exists for this date"})
+		return
+	}
+
+	warnings, err := overtimeWarnings(actor.ID, input.Date.Time, hours, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check overtime caps"})
+		return
+	}
+
+	request := models.OvertimeRequest{
+		EmployeeID:   actor.ID,
+		Date:         input.Date,
+		StartTime:    input.StartTime,
+		EndTime:      input.EndTime,
+		PlannedHours: hours,
+		Reason:       input.Reason,
+		Status:       models.RequestPending,
+	}
+	if err := config.GetDB().Create(&request).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create overtime request"})
+		return
+	}
+	c.JSON(http.StatusCreated, OvertimeResponse{Request: request, Warnings: warnings})
+}
+
+// ApproveOvertimeRequest godoc
+// @Summary Approve an overtime request
+// @Description A manager or the requester's manager in the reporting line pre-approves overtime; cap warnings are returned but do not block approval
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Param id path int true "Overtime request ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Optional comment"
+// @Success 200 {object} OvertimeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/overtime/{id}/approve [post]
+func ApproveOvertimeRequest(c *gin.Context) {
+	reviewOvertimeRequest(c, models.RequestApproved)
+}
+
+// RejectOvertimeRequest godoc
+// @Summary Reject an overtime request
+// @Description A manager or the requester's manager in the reporting line rejects a pending overtime request
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Param id path int true "Overtime request ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Optional comment"
+// @Success 200 {object} OvertimeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/overtime/{id}/reject [post]
+func RejectOvertimeRequest(c *gin.Context) {
+	reviewOvertimeRequest(c, models.RequestRejected)
+}
+
+// reviewOvertimeRequest duyệt hoặc từ chối đơn làm thêm giờ đang chờ
+func reviewOvertimeRequest(c *gin.Context, status string) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	var body SalaryApprovalRequest
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	var request models.OvertimeRequest
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&request).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Overtime request not found"})
+		return
+	}
+	if request.Status != models.RequestPending {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Overtime request is not pending"})
+		return
+	}
+	if request.EmployeeID == actor.ID {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "You cannot review your own overtime request"})
+		return
+	}
+	if !canApproveFor(actor, request.EmployeeID) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can review overtime requests"})
+		return
+	}
+
+	warnings := []string{}
+	if status == models.RequestApproved {
+		var err error
+		if warnings, err = overtimeWarnings(request.EmployeeID, request.Date.Time, request.PlannedHours, request.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check overtime caps"})
+			return
+		}
+	}
+
+	now := time.Now()
+	request.Status = status
+	request.ReviewedByID = &actor.ID
+	request.ReviewedAt = &now
+	request.ReviewComment = body.Comment
+	if err := config.GetDB().Save(&request).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update overtime request"})
+		return
+	}
+	c.JSON(http.StatusOK, OvertimeResponse{Request: request, Warnings: warnings})
+}
+
+// RecordOvertime godoc
+// @Summary Record actual overtime
+// @Description A manager who can approve the request records the hours actually worked. Hours are split into weekday, weekend or holiday and night categories; only the approved hours are paid and unpaid hours recorded earlier are replaced.
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Param id path int true "Overtime request ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param record body OvertimeRecordInput true "Actual start and end time"
+// @Success 200 {object} OvertimeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/overtime/{id}/record [post]
+func RecordOvertime(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var input OvertimeRecordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	var request models.OvertimeRequest
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&request).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Overtime request not found"})
+		return
+	}
+	// Người ghi nhận giờ thực tế phải là người có thể duyệt đơn, không tự ghi cho mình
+	if request.EmployeeID == actor.ID {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "You cannot record your own overtime"})
+		return
+	}
+	if !canApproveFor(actor, request.EmployeeID) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can record overtime"})
+		return
+	}
+	if request.Status != models.RequestApproved {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Only approved overtime can be recorded"})
+		return
+	}
+
+	var paid int64
+	if err := config.GetDB().Model(&models.OvertimeEntry{}).
+		Where("overtime_request_id = ? AND salary_id IS NOT NULL", request.ID).
+		Count(&paid).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check recorded overtime"})
+		return
+	}
+	if paid > 0 {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Overtime has already been included in a salary"})
+		return
+	}
+
+	day, night, err := overtimePolicy().SplitHours(input.StartTime, input.EndTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	// Chỉ trả cho số giờ đã được duyệt, phần vượt là những giờ cuối của khoảng làm thêm
+	worked := day + night
+	if worked > request.PlannedHours {
+		end, err := payroll.ClockAfter(input.StartTime, request.PlannedHours)
+		if err == nil {
+			day, night, err = overtimePolicy().SplitHours(input.StartTime, end)
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+	category, err := overtimeCategory(request.Date.Time)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to determine overtime category"})
+		return
+	}
+
+	entries := []models.OvertimeEntry{}
+	for _, part := range []struct {
+		night bool
+		hours float64
+	}{{false, day}, {true, night}} {
+		if part.hours > 0 {
+			entries = append(entries, models.OvertimeEntry{
+				OvertimeRequestID: request.ID,
+				EmployeeID:        request.EmployeeID,
+				Date:              request.Date,
+				Category:          category,
+				Night:             part.night,
+				Hours:             part.hours,
+			})
+		}
+	}
+
+	// Giới hạn giờ làm thêm tính theo số giờ làm thực tế
+	request.Recorded = true
+	request.RecordedHours = worked
+	warnings, err := overtimeWarnings(request.EmployeeID, request.Date.Time, request.RecordedHours, request.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check overtime caps"})
+		return
+	}
+	if worked > request.PlannedHours {
+		warnings = append(warnings, fmt.Sprintf("Recorded %.1fh exceed the approved %.1fh; only the approved hours are paid", worked, request.PlannedHours))
+	}
+
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("overtime_request_id = ?", request.ID).Delete(&models.OvertimeEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&entries).Error; err != nil {
+			return err
+		}
+		return tx.Save(&request).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to record overtime"})
+		return
+	}
+
+	request.Entries = entries
+	c.JSON(http.StatusOK, OvertimeResponse{Request: request, Warnings: warnings})
+}
+
+// GetEmployeeOvertimeSummary godoc
+// @Summary Get overtime summary
+// @Description Get an employee's overtime hours for a month and its year, compared with the caps
+// @Tags Overtime
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param year query int false "Year, defaults to the current year"
+// @Param month query int false "Month, defaults to the current month"
+// @Success 200 {object} OvertimeSummary
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/overtime [get]
+func GetEmployeeOvertimeSummary(c *gin.Context) {
+	var employee models.Employee
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&employee).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	now := time.Now()
+	year, month := now.Year(), int(now.Month())
+	var err error
+	if value := c.Query("year"); value != "" {
+		if year, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid year format"})
+			return
+		}
+	}
+	if value := c.Query("month"); value != "" {
+		if month, err = strconv.Atoi(value); err != nil || month < 1 || month > 12 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid month format"})
+			return
+		}
+	}
+
+	policy := overtimePolicy()
+	summary := OvertimeSummary{
+		EmployeeID:      employee.ID,
+		Year:            year,
+		Month:           month,
+		MonthlyCapHours: policy.MonthlyCapHours,
+		YearlyCapHours:  policy.YearlyCapHours,
+		ByCategory:      map[string]float64{},
+	}
+
+	monthStart, monthEnd := models.PeriodBounds(year, month)
+	yearStart, _ := models.PeriodBounds(year, 1)
+	_, yearEnd := models.PeriodBounds(year, 12)
+	if summary.MonthHours, err = overtimeHours(employee.ID, monthStart, monthEnd, 0); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to sum overtime hours"})
+		return
+	}
+	if summary.YearHours, err = overtimeHours(employee.ID, yearStart, yearEnd, 0); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to sum overtime hours"})
+		return
+	}
+
+	var entries []models.OvertimeEntry
+	if err := config.GetDB().
+		Where("employee_id = ? AND date BETWEEN ? AND ?", employee.ID, calendar.DateKey(monthStart), calendar.DateKey(monthEnd)).
+		Find(&entries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch overtime entries"})
+		return
+	}
+	for _, entry := range entries {
+		key := entry.Category
+		if entry.Night {
+			key += "_night"
+		}
+		summary.ByCategory[key] += entry.Hours
+	}
+
+	summary.Warnings = policy.CapWarnings(summary.MonthHours, summary.YearHours)
+	c.JSON(http.StatusOK, summary)
+}
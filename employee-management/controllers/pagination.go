@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Kích thước trang mặc định và tối đa của các danh sách có phân trang
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// pagination là trang được yêu cầu qua tham số page và page_size
+type pagination struct {
+	Page     int
+	PageSize int
+}
+
+// parsePagination đọc page (bắt đầu từ 1) và page_size từ query string.
+// Nếu không hợp lệ, hàm tự trả lỗi về client và trả về false.
+func parsePagination(c *gin.Context) (pagination, bool) {
+	p := pagination{Page: 1, PageSize: defaultPageSize}
+	if page := c.Query("page"); page != "" {
+		value, err := strconv.Atoi(page)
+		if err != nil || value < 1 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "page must be a positive integer"})
+			return p, false
+		}
+		p.Page = value
+	}
+	if size := c.Query("page_size"); size != "" {
+		value, err := strconv.Atoi(size)
+		if err != nil || value < 1 || value > maxPageSize {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("page_size must be between 1 and %d", maxPageSize)})
+			return p, false
+		}
+		p.PageSize = value
+	}
+	return p, true
+}
+
+// apply giới hạn truy vấn vào trang được yêu cầu
+func (p pagination) apply(query *gorm.DB) *gorm.DB {
+	return query.Offset((p.Page - 1) * p.PageSize).Limit(p.PageSize)
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // positionExists checks if a position already exists by its title.
@@ -66,26 +67,36 @@ func GetPositionByID(c *gin.Context) {
 
 // GetEmployeesByPosition godoc
 // @Summary Get employees by position
-// @Description Retrieve employees by their position
+// @Description Retrieve a page of employees holding a position on a given day
 // @Tags Position
 // @Accept json
 // @Produce json
 // @Param position_id path int true "Position ID"
-// @Success 200 {array} models.Employee
+// @Param as_of query string false "Date (YYYY-MM-DD), defaults to today"
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Page size (max 100)"
+// @Success 200 {object} EmployeeSummaryPage
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /api/v1/positions/{position_id}/employees [get]
 func GetEmployeesByPosition(c *gin.Context) {
-	positionID := c.Param("position_id")
-
-	var employees []models.Employee
-
-	// Query employees with the specified position ID
-	if err := config.GetDB().Where("position_id = ?", positionID).Find(&employees).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to fetch employees for this position"})
+	positionID, err := strconv.Atoi(c.Param("position_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid position ID"})
+		return
+	}
+	asOf, ok := parseAsOf(c)
+	if !ok {
+		return
+	}
+	var position models.Position
+	if err := config.GetDB().First(&position, positionID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Position not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, employees)
+	listMembers(c, "employee_positions", "position_id", []uint{position.ID}, asOf)
 }
 
 // CreatePosition godoc
@@ -107,6 +118,11 @@ func CreatePosition(c *gin.Context) {
 		return
 	}
 
+	if err := validatePositionCatalog(position); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
 	// Check if the position already exists
 	if positionExists(position.Title) {
 		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Position already exists"})
@@ -150,6 +166,10 @@ func UpdatePosition(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid input data"})
 		return
 	}
+	if err := validatePositionCatalog(position); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
 
 	if err := config.GetDB().Save(&position).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to update position"})
@@ -161,11 +181,12 @@ func UpdatePosition(c *gin.Context) {
 
 // DeletePosition godoc
 // @Summary Delete a position
-// @Description Remove a position from the system
+// @Description Remove a position from the system. A position held by employees can only be deleted when reassign_to names another position; its holders are moved there in the same transaction. Past membership history is kept and still shows the position title.
 // @Tags Position
 // @Accept json
 // @Produce json
 // @Param id path int true "Position ID"
+// @Param reassign_to query int false "Position that receives the employees"
 // @Success 200 {object} models.ResponseMessage
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
@@ -180,20 +201,55 @@ func DeletePosition(c *gin.Context) {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Position not found"})
 		return
 	}
+	reassignTo, ok := parseReassignTo(c, position.ID)
+	if !ok {
+		return
+	}
 
 	// Check if any employees are assigned to the position
-	var employees []models.Employee
-	if err := config.GetDB().Where("position_id = ?", id).Find(&employees).Error; err != nil {
+	members, err := countMembers("employee_positions", "position_id", position.ID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to check employees in position"})
 		return
 	}
-	if len(employees) > 0 {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Cannot delete position because it has employees"})
+	if members > 0 && reassignTo == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Cannot delete position because it has employees; pass reassign_to to move them"})
 		return
 	}
+	// Open vacancies must be filled or cancelled first
+	var vacancies int64
+	if err := config.GetDB().Model(&models.Vacancy{}).Where("position_id = ? AND status = ?", position.ID, models.VacancyOpen).Count(&vacancies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to check vacancies for position"})
+		return
+	}
+	if vacancies > 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Cannot delete position because it has open vacancies"})
+		return
+	}
+	if reassignTo != 0 {
+		var target models.Position
+		if err := config.GetDB().First(&target, reassignTo).Error; err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Target position not found"})
+			return
+		}
+	}
 
-	// Delete the position
-	if err := config.GetDB().Delete(&position).Error; err != nil {
+	// Delete the position, moving its holders first when requested
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		if reassignTo != 0 {
+			if err := reassignMembers(tx, "employee_positions", "position_id", position.ID, reassignTo); err != nil {
+				return err
+			}
+		}
+		if err := closeMemberships(tx, "employee_positions", "position_id", position.ID); err != nil {
+			return err
+		}
+		if err := tx.Where("position_id = ?", position.ID).Delete(&models.HeadcountPlan{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&position).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to delete position"})
 		return
 	}
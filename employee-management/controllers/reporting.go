@@ -0,0 +1,389 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxManagementDepth giới hạn số cấp quản lý khi đi ngược tuyến báo cáo
+const maxManagementDepth = 50
+
+// SetManagerRequest là quản lý trực tiếp mới của nhân viên
+type SetManagerRequest struct {
+	ManagerID *uint `json:"manager_id"` // nil để bỏ quản lý trực tiếp
+}
+
+// SetDepartmentHeadRequest là trưởng phòng ban mới
+type SetDepartmentHeadRequest struct {
+	HeadID *uint `json:"head_id"` // nil để bỏ trưởng phòng ban
+}
+
+// ReportEntry là một nhân viên cấp dưới kèm cấp cách người quản lý (1 là trực tiếp)
+type ReportEntry struct {
+	EmployeeSummary
+	Level int `json:"level"`
+}
+
+// validateManager kiểm tra quản lý trực tiếp tồn tại và không tạo vòng lặp trong tuyến báo cáo.
+// employeeID bằng 0 khi nhân viên chưa được tạo.
+func validateManager(employeeID uint, managerID *uint) error {
+	if managerID == nil {
+		return nil
+	}
+	if employeeID != 0 && *managerID == employeeID {
+		return fmt.Errorf("An employee cannot be their own manager")
+	}
+	var manager models.Employee
+	if err := config.GetDB().First(&manager, *managerID).Error; err != nil {
+		return fmt.Errorf("Manager not found")
+	}
+	if employeeID == 0 {
+		return nil
+	}
+
+	// Đi ngược tuyến quản lý trực tiếp của người quản lý mới, không được gặp lại nhân viên
+	current := manager
+	for depth := 0; current.ManagerID != nil && depth < maxManagementDepth; depth++ {
+		if *current.ManagerID == employeeID {
+			return fmt.Errorf("This manager reports to the employee, which would create a cycle")
+		}
+		var next models.Employee
+		if err := config.GetDB().First(&next, *current.ManagerID).Error; err != nil {
+			return nil
+		}
+		current = next
+	}
+	return nil
+}
+
+// sameManager kiểm tra hai quản lý trực tiếp có giống nhau hay không
+func sameManager(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// authorizeManagerChange kiểm tra người thực hiện là HR manager trước khi đổi quản lý trực tiếp,
+// vì quản lý trực tiếp được duyệt nghỉ phép, làm thêm giờ và chi phí của nhân viên.
+// Nếu không hợp lệ, hàm tự trả lỗi về client và trả về false.
+func authorizeManagerChange(c *gin.Context) bool {
+	actor, ok := currentActor(c)
+	if !ok {
+		return false
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can change reporting lines"})
+		return false
+	}
+	return true
+}
+
+// reportingIndex là ảnh chụp nhân viên, phòng ban và phòng ban hiện tại của từng nhân viên,
+// dùng để xác định người quản lý mà không phải truy vấn lại cho từng nhân viên
+type reportingIndex struct {
+	employees   map[uint]models.Employee
+	departments map[uint]models.Department
+	memberships map[uint][]uint // Phòng ban của nhân viên, phòng ban chính đứng trước
+}
+
+// loadReportingIndex tải toàn bộ dữ liệu cần để xác định tuyến quản lý
+func loadReportingIndex() (*reportingIndex, error) {
+	var employees []models.Employee
+	if err := config.GetDB().Order("id").Find(&employees).Error; err != nil {
+		return nil, err
+	}
+	byID, _, err := departmentChildren()
+	if err != nil {
+		return nil, err
+	}
+	var memberships []models.EmployeeDepartment
+	if err := activeDepartments(config.GetDB()).Order("is_primary DESC, department_id").Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+
+	index := &reportingIndex{
+		employees:   make(map[uint]models.Employee, len(employees)),
+		departments: byID,
+		memberships: map[uint][]uint{},
+	}
+	for _, employee := range employees {
+		index.employees[employee.ID] = employee
+	}
+	for _, membership := range memberships {
+		index.memberships[membership.EmployeeID] = append(index.memberships[membership.EmployeeID], membership.DepartmentID)
+	}
+	return index, nil
+}
+
+// departmentHeadFor tìm trưởng phòng ban gần nhất của nhân viên, đi dần lên phòng ban cấp trên,
+// bỏ qua trường hợp chính nhân viên là trưởng phòng ban
+func (r *reportingIndex) departmentHeadFor(employee models.Employee) *models.Employee {
+	for _, departmentID := range r.memberships[employee.ID] {
+		department, ok := r.departments[departmentID]
+		for depth := 0; ok && depth < maxManagementDepth; depth++ {
+			if department.HeadID != nil && *department.HeadID != employee.ID {
+				if head, ok := r.employees[*department.HeadID]; ok {
+					return &head
+				}
+			}
+			if department.ParentID == nil {
+				break
+			}
+			department, ok = r.departments[*department.ParentID]
+		}
+	}
+	return nil
+}
+
+// resolveManager trả về người quản lý của nhân viên: quản lý trực tiếp nếu có,
+// nếu không thì trưởng phòng ban gần nhất. Trả về nil nếu không xác định được.
+func (r *reportingIndex) resolveManager(employee models.Employee) *models.Employee {
+	if employee.ManagerID != nil {
+		if manager, ok := r.employees[*employee.ManagerID]; ok {
+			return &manager
+		}
+	}
+	return r.departmentHeadFor(employee)
+}
+
+// managementChain trả về tuyến quản lý của nhân viên từ cấp gần nhất lên cấp cao nhất
+func managementChain(employee models.Employee) ([]models.Employee, error) {
+	index, err := loadReportingIndex()
+	if err != nil {
+		return nil, err
+	}
+	chain := []models.Employee{}
+	seen := map[uint]bool{employee.ID: true}
+	current := employee
+	for len(chain) < maxManagementDepth {
+		manager := index.resolveManager(current)
+		if manager == nil || seen[manager.ID] {
+			break
+		}
+		seen[manager.ID] = true
+		chain = append(chain, *manager)
+		current = *manager
+	}
+	return chain, nil
+}
+
+// isManagerOf kiểm tra actor có nằm trong tuyến quản lý của nhân viên hay không
+func isManagerOf(actor models.Employee, employeeID uint) bool {
+	var employee models.Employee
+	if err := config.GetDB().First(&employee, employeeID).Error; err != nil {
+		return false
+	}
+	chain, err := managementChain(employee)
+	if err != nil {
+		return false
+	}
+	for _, manager := range chain {
+		if manager.ID == actor.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// canApproveFor kiểm tra actor được duyệt yêu cầu của nhân viên: có vai trò quản lý
+// hoặc là người quản lý của nhân viên trong tuyến báo cáo
+func canApproveFor(actor models.Employee, employeeID uint) bool {
+	return actor.ID != employeeID && (hasRole(actor, managerRoles...) || isManagerOf(actor, employeeID))
+}
+
+// GetManagementChain godoc
+// @Summary Get an employee's management chain
+// @Description List the employee's managers from the closest upwards. Each step uses the direct manager, or the nearest department head when none is set.
+// @Tags Employee
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 200 {array} EmployeeSummary
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/management-chain [get]
+func GetManagementChain(c *gin.Context) {
+	var employee models.Employee
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&employee).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	chain, err := managementChain(employee)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to resolve management chain"})
+		return
+	}
+	result := make([]EmployeeSummary, 0, len(chain))
+	for _, manager := range chain {
+		result = append(result, summarizeEmployee(manager))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetEmployeeReports godoc
+// @Summary Get an employee's reports
+// @Description List employees managed by this employee, either as direct manager or as the nearest department head when they have none, and with indirect=true everyone below them in the reporting line
+// @Tags Employee
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param indirect query bool false "Include indirect reports"
+// @Success 200 {array} ReportEntry
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/reports [get]
+func GetEmployeeReports(c *gin.Context) {
+	var employee models.Employee
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&employee).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+	indirect, _ := strconv.ParseBool(c.Query("indirect"))
+
+	// Cấp dưới được xác định theo cùng quy tắc với tuyến quản lý: quản lý trực tiếp hoặc trưởng phòng ban
+	index, err := loadReportingIndex()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch reports"})
+		return
+	}
+	direct := map[uint][]models.Employee{}
+	for _, report := range index.employees {
+		if manager := index.resolveManager(report); manager != nil {
+			direct[manager.ID] = append(direct[manager.ID], report)
+		}
+	}
+	for _, employees := range direct {
+		sort.Slice(employees, func(i, j int) bool {
+			if employees[i].Name != employees[j].Name {
+				return employees[i].Name < employees[j].Name
+			}
+			return employees[i].ID < employees[j].ID
+		})
+	}
+
+	reports := []ReportEntry{}
+	seen := map[uint]bool{employee.ID: true}
+	level := []uint{employee.ID}
+	for depth := 1; len(level) > 0 && depth <= maxManagementDepth; depth++ {
+		var next []uint
+		for _, managerID := range level {
+			for _, report := range direct[managerID] {
+				if seen[report.ID] {
+					continue
+				}
+				seen[report.ID] = true
+				reports = append(reports, ReportEntry{EmployeeSummary: summarizeEmployee(report), Level: depth})
+				next = append(next, report.ID)
+			}
+		}
+		level = next
+		if !indirect {
+			break
+		}
+	}
+	c.JSON(http.StatusOK, reports)
+}
+
+// SetEmployeeManager godoc
+// @Summary Set an employee's direct manager
+// @Description An HR manager sets or clears the direct manager; reporting-line cycles are rejected
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SetManagerRequest true "Direct manager"
+// @Success 200 {object} EmployeeSummary
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/manager [put]
+func SetEmployeeManager(c *gin.Context) {
+	if !authorizeManagerChange(c) {
+		return
+	}
+
+	var employee models.Employee
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&employee).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+
+	var request SetManagerRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	if err := validateManager(employee.ID, request.ManagerID); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := config.GetDB().Model(&employee).Update("manager_id", request.ManagerID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update manager"})
+		return
+	}
+	employee.ManagerID = request.ManagerID
+	c.JSON(http.StatusOK, summarizeEmployee(employee))
+}
+
+// SetDepartmentHead godoc
+// @Summary Set a department head
+// @Description An HR manager sets or clears the head of a department; the head manages department members without a direct manager
+// @Tags Department
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SetDepartmentHeadRequest true "Department head"
+// @Success 200 {object} models.Department
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/departments/{id}/head [put]
+func SetDepartmentHead(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can set department heads"})
+		return
+	}
+
+	var department models.Department
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&department).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Department not found"})
+		return
+	}
+
+	var request SetDepartmentHeadRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	if request.HeadID != nil {
+		var head models.Employee
+		if err := config.GetDB().First(&head, *request.HeadID).Error; err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Head employee not found"})
+			return
+		}
+	}
+
+	if err := config.GetDB().Model(&department).Update("head_id", request.HeadID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update department head"})
+		return
+	}
+	department.HeadID = request.HeadID
+	c.JSON(http.StatusOK, department)
+}
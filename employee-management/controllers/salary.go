@@ -3,15 +3,131 @@ package controllers
 import (
 	"employee-management/config"
 	"employee-management/models"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+// salaryFilter là bộ lọc chung cho danh sách và thống kê bảng lương
+type salaryFilter struct {
+	Month   int
+	Quarter int
+	Year    int
+	Status  string
+}
+
+// parseSalaryFilter đọc các tham số month, quarter, year, status từ query string
+func parseSalaryFilter(c *gin.Context) (salaryFilter, error) {
+	var filter salaryFilter
+	var err error
+
+	if month := c.Query("month"); month != "" {
+		if filter.Month, err = strconv.Atoi(month); err != nil || filter.Month < 1 || filter.Month > 12 {
+			return filter, fmt.Errorf("Invalid month format")
+		}
+	}
+	if quarter := c.Query("quarter"); quarter != "" {
+		if filter.Quarter, err = strconv.Atoi(quarter); err != nil || filter.Quarter < 1 || filter.Quarter > 4 {
+			return filter, fmt.Errorf("Invalid quarter format")
+		}
+	}
+	if year := c.Query("year"); year != "" {
+		if filter.Year, err = strconv.Atoi(year); err != nil {
+			return filter, fmt.Errorf("Invalid year format")
+		}
+	}
+	if status := c.Query("status"); status != "" {
+		// Ensure status is either 'Chưa thanh toán' or 'Đã thanh toán'
+		if status != models.SalaryUnpaid && status != models.SalaryPaid {
+			return filter, fmt.Errorf("Invalid status. Must be '%s' or '%s'", models.SalaryUnpaid, models.SalaryPaid)
+		}
+		filter.Status = status
+	}
+
+	return filter, nil
+}
+
+// apply thêm điều kiện lọc vào truy vấn bảng salaries
+func (f salaryFilter) apply(query *gorm.DB) *gorm.DB {
+	// Lọc theo kỳ lương, không theo ngày tạo bảng lương
+	if f.Month != 0 {
+		query = query.Where("salaries.period_month = ?", f.Month)
+	}
+	if f.Quarter != 0 {
+		query = query.Where("salaries.period_month BETWEEN ? AND ?", (f.Quarter-1)*3+1, f.Quarter*3)
+	}
+	if f.Year != 0 {
+		query = query.Where("salaries.period_year = ?", f.Year)
+	}
+	if f.Status != "" {
+		query = query.Where("salaries.status = ?", f.Status)
+	}
+	return query
+}
+
+// calculateTotalSalary tính tổng lương: lương theo ngày công cộng thưởng trừ phạt.
+// Ngày công lẻ nửa ngày được tính theo đơn giá ngày rồi làm tròn đến đồng.
+func calculateTotalSalary(salary *models.Salary) {
+	if salary.Coefficient == 0 {
+		salary.Coefficient = standardWorkingDays()
+	}
+	dailyRate := salary.BasicSalary / salary.Coefficient
+	salary.TotalSalary = int(math.Round(float64(dailyRate)*salary.WorkingDays)) + salary.OvertimePay + salary.Reimbursement + salary.Bonus - salary.Fine - salary.AdvanceDeduction
+}
+
+// standardWorkingDays là số ngày công chuẩn của một tháng, cấu hình qua PAYROLL_STANDARD_WORKING_DAYS
+func standardWorkingDays() int {
+	if days, err := strconv.Atoi(config.GetEnv("PAYROLL_STANDARD_WORKING_DAYS")); err == nil && days > 0 {
+		return days
+	}
+	return 26
+}
+
+// normalizeSalaryPeriod kiểm tra kỳ lương và điền ngày đầu/cuối kỳ nếu chưa có
+func normalizeSalaryPeriod(salary *models.Salary) error {
+	if salary.PeriodYear < 2000 || salary.PeriodMonth < 1 || salary.PeriodMonth > 12 {
+		return fmt.Errorf("A valid period_year and period_month (1-12) are required")
+	}
+	if salary.Type == "" {
+		salary.Type = models.SalaryRegular
+	}
+	if salary.Type != models.SalaryRegular && salary.Type != models.SalarySupplementary {
+		return fmt.Errorf("Invalid type. Must be '%s' or '%s'", models.SalaryRegular, models.SalarySupplementary)
+	}
+
+	start, end := models.PeriodBounds(salary.PeriodYear, salary.PeriodMonth)
+	if salary.PeriodStart.IsZero() {
+		salary.PeriodStart = models.CustomTime{Time: start}
+	}
+	if salary.PeriodEnd.IsZero() {
+		salary.PeriodEnd = models.CustomTime{Time: end}
+	}
+	if salary.PeriodEnd.Before(salary.PeriodStart.Time) {
+		return fmt.Errorf("period_end must not be before period_start")
+	}
+	return nil
+}
+
+// regularSalaryExists kiểm tra nhân viên đã có bảng lương chính thức trong kỳ hay chưa
+func regularSalaryExists(salary models.Salary) (bool, error) {
+	if salary.Type != models.SalaryRegular {
+		return false, nil
+	}
+	var count int64
+	err := config.GetDB().Model(&models.Salary{}).
+		Where("employee_id = ? AND period_year = ? AND period_month = ? AND type = ? AND id <> ?",
+			salary.EmployeeID, salary.PeriodYear, salary.PeriodMonth, models.SalaryRegular, salary.ID).
+		Count(&count).Error
+	return count > 0, err
+}
+
 // GetSalaries godoc
 // @Summary Get list of salaries with filters
-// @Description Get salaries with optional filters for month, quarter, year, and payment status
+// @Description Get salaries with optional filters for pay period month, quarter, year, and payment status
 // @Tags Salary
 // @Accept json
 // @Produce json
@@ -23,46 +139,14 @@ import (
 // @Failure 400 {object} ErrorResponse
 // @Router /api/v1/salaries [get]
 func GetSalaries(c *gin.Context) {
-	// Get query parameters from the URL
-	month := c.DefaultQuery("month", "")
-	quarter := c.DefaultQuery("quarter", "")
-	year := c.DefaultQuery("year", "")
-	status := c.DefaultQuery("status", "")
-
-	var salaries []models.Salary
-	query := config.GetDB().Model(&models.Salary{})
-
-	// Handle 'month' filter
-	if month != "" {
-		monthInt, err := strconv.Atoi(month)
-		if err == nil {
-			// PostgreSQL: EXTRACT(MONTH FROM created_at) instead of MONTH
-			query = query.Where("EXTRACT(MONTH FROM created_at) = ?", monthInt)
-		}
-	}
-
-	// Handle 'quarter' filter
-	if quarter != "" {
-		quarterInt, err := strconv.Atoi(quarter)
-		if err == nil {
-			// Calculate quarter using EXTRACT: (Month / 3) and rounding up.
-			query = query.Where("EXTRACT(QUARTER FROM created_at) = ?", quarterInt)
-		}
-	}
-
-	// Handle 'year' filter
-	if year != "" {
-		yearInt, err := strconv.Atoi(year)
-		if err == nil {
-			// PostgreSQL: EXTRACT(YEAR FROM created_at) instead of YEAR
-			query = query.Where("EXTRACT(YEAR FROM created_at) = ?", yearInt)
-		}
+	filter, err := parseSalaryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
 	}
 
-	// Handle 'status' filter
-	if status != "" {
-		query = query.Where("status = ?", status)
-	}
+	var salaries []models.Salary
+	query := filter.apply(config.GetDB().Model(&models.Salary{}))
 
 	// Execute the query to fetch salaries
 	if err := query.Find(&salaries).Error; err != nil {
@@ -93,7 +177,7 @@ func GetSalaryByID(c *gin.Context) {
 	}
 
 	var salary models.Salary
-	if err := config.GetDB().Preload("Employee").First(&salary, id).Error; err != nil {
+	if err := config.GetDB().Preload("Employee").Preload("Approvals").Preload("EarningLines").Preload("AdvanceRepayments").First(&salary, id).Error; err != nil {
 		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Salary not found"})
 		return
 	}
@@ -118,6 +202,8 @@ func CreateSalary(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
 		return
 	}
+	// Lịch sử phê duyệt chỉ được ghi qua quy trình phê duyệt
+	salary.Approvals = nil
 
 	// Kiểm tra xem EmployeeID có tồn tại trong hệ thống không
 	var employee models.Employee
@@ -126,11 +212,32 @@ func CreateSalary(c *gin.Context) {
 		return
 	}
 
+	// Kiểm tra kỳ lương và quy tắc một bảng lương chính thức mỗi kỳ
+	if err := normalizeSalaryPeriod(&salary); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if exists, err := regularSalaryExists(salary); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check existing salaries"})
+		return
+	} else if exists {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Employee already has a regular salary for this period"})
+		return
+	}
+
+	// Tiền làm thêm giờ, hoàn trả chi phí và khoản trừ tạm ứng chỉ được tính tự động khi lập bảng lương theo kỳ
+	salary.OvertimePay = 0
+	salary.Reimbursement = 0
+	salary.EarningLines = nil
+	salary.AdvanceDeduction = 0
+	salary.AdvanceRepayments = nil
+
 	// Tính toán total_salary
-	salary.TotalSalary = (salary.BasicSalary/salary.Coefficient)*int(salary.WorkingDays) + salary.Bonus - salary.Fine
+	calculateTotalSalary(&salary)
 
-	// Thiết lập trạng thái mặc định là "Chưa thanh toán"
-	salary.Status = "Chưa thanh toán"
+	// Thiết lập trạng thái mặc định là "Chưa thanh toán" và bắt đầu quy trình phê duyệt ở bước nháp
+	salary.Status = models.SalaryUnpaid
+	salary.ApprovalStatus = models.SalaryDraft
 
 	// Lưu bảng lương vào cơ sở dữ liệu
 	if err := config.GetDB().Create(&salary).Error; err != nil {
@@ -138,6 +245,8 @@ func CreateSalary(c *gin.Context) {
 		return
 	}
 
+	// Cảnh báo khi lương cơ bản nằm ngoài khung lương của chức vụ
+	writeBandWarnings(c, salary.EmployeeID, salary.BasicSalary)
 	c.JSON(http.StatusCreated, salary)
 }
 
@@ -167,29 +276,68 @@ func UpdateSalary(c *gin.Context) {
 		return
 	}
 
+	// Chỉ bảng lương nháp mới được chỉnh sửa
+	if salary.ApprovalStatus != models.SalaryDraft {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Only draft salaries can be updated"})
+		return
+	}
+
+	overtimePay, reimbursement, advanceDeduction := salary.OvertimePay, salary.Reimbursement, salary.AdvanceDeduction
 	if err := c.ShouldBindJSON(&salary); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
 		return
 	}
-	salary.TotalSalary = (salary.BasicSalary/salary.Coefficient)*int(salary.WorkingDays) + salary.Bonus - salary.Fine
+	// Luôn ghi vào bảng lương theo ID trên đường dẫn, lịch sử phê duyệt chỉ được ghi qua quy trình phê duyệt
+	salary.ID = uint(id)
+	salary.Approvals = nil
+	// Tiền làm thêm giờ, hoàn trả chi phí và khoản trừ tạm ứng được tính tự động, không sửa trực tiếp
+	salary.OvertimePay = overtimePay
+	salary.Reimbursement = reimbursement
+	salary.EarningLines = nil
+	salary.AdvanceDeduction = advanceDeduction
+	salary.AdvanceRepayments = nil
+	// Trạng thái chỉ được thay đổi qua quy trình phê duyệt và thanh toán
+	salary.ApprovalStatus = models.SalaryDraft
+	salary.Status = models.SalaryUnpaid
+	salary.PaymentReference = ""
+	salary.PaidAt = nil
+
+	if err := normalizeSalaryPeriod(&salary); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if exists, err := regularSalaryExists(salary); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check existing salaries"})
+		return
+	} else if exists {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Employee already has a regular salary for this period"})
+		return
+	}
+
+	calculateTotalSalary(&salary)
 	// Cập nhật bảng lương
 	if err := config.GetDB().Save(&salary).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update salary"})
 		return
 	}
+	writeBandWarnings(c, salary.EmployeeID, salary.BasicSalary)
 	c.JSON(http.StatusOK, salary)
 }
 
 // DeleteSalary godoc
 // @Summary Delete a salary
-// @Description Remove a salary record by its ID
+// @Description Payroll removes a draft salary record by its ID; salaries already in the approval workflow or paid cannot be deleted
 // @Tags Salary
 // @Accept json
 // @Produce json
 // @Param id path int true "Salary ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
 // @Success 200 {object} ResponseMessage
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/salaries/{id} [delete]
 func DeleteSalary(c *gin.Context) {
@@ -199,14 +347,44 @@ func DeleteSalary(c *gin.Context) {
 		return
 	}
 
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, payrollRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only payroll can delete salaries"})
+		return
+	}
+
 	var salary models.Salary
 	if err := config.GetDB().First(&salary, id).Error; err != nil {
 		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Salary not found"})
 		return
 	}
 
-	// Xóa bảng lương khỏi cơ sở dữ liệu
-	if err := config.GetDB().Delete(&salary).Error; err != nil {
+	// Bảng lương đã vào quy trình phê duyệt hoặc đã thanh toán không được xóa
+	if salary.ApprovalStatus != models.SalaryDraft {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Only draft salaries can be deleted"})
+		return
+	}
+
+	// Xóa bảng lương nháp khỏi cơ sở dữ liệu, giờ làm thêm, chi phí đã hoàn trả và khoản trừ tạm ứng được hoàn lại để tính ở bảng lương khác
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.OvertimeEntry{}).Where("salary_id = ?", salary.ID).Update("salary_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("salary_id = ?", salary.ID).Delete(&models.SalaryEarningLine{}).Error; err != nil {
+			return err
+		}
+		if err := revertAdvanceRepayments(tx, salary.ID); err != nil {
+			return err
+		}
+		if err := revertReimbursements(tx, salary.ID); err != nil {
+			return err
+		}
+		return tx.Delete(&salary).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to delete salary"})
 		return
 	}
@@ -215,14 +393,18 @@ func DeleteSalary(c *gin.Context) {
 
 // PaySalary godoc
 // @Summary Mark a salary as paid
-// @Description Mark a salary record as paid by changing the status to "Đã thanh toán"
+// @Description Mark an approved salary record as paid by changing the status to "Đã thanh toán"
 // @Tags Salary
 // @Accept json
 // @Produce json
 // @Param id path int true "Salary ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
 // @Success 200 {object} models.Salary
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /api/v1/salaries/{id}/pay [put]
 func PaySalary(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -231,15 +413,36 @@ func PaySalary(c *gin.Context) {
 		return
 	}
 
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, financeRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only finance can pay salaries"})
+		return
+	}
+
 	var salary models.Salary
 	if err := config.GetDB().First(&salary, id).Error; err != nil {
 		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Salary not found"})
 		return
 	}
 
-	// Cập nhật trạng thái thành "Đã thanh toán"
-	salary.Status = "Đã thanh toán"
-	if err := config.GetDB().Save(&salary).Error; err != nil {
+	// Chỉ bảng lương đã được phê duyệt và chưa thanh toán mới được thanh toán
+	if salary.ApprovalStatus != models.SalaryApproved {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Only approved salaries can be paid"})
+		return
+	}
+	if salary.Status == models.SalaryPaid {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Salary has already been paid"})
+		return
+	}
+
+	// Cập nhật trạng thái thành "Đã thanh toán" và ghi lại người thực hiện
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		return markSalaryPaid(tx, &salary, actor, "")
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update salary"})
 		return
 	}
@@ -249,11 +452,12 @@ func PaySalary(c *gin.Context) {
 
 // GetSalaryStatistics godoc
 // @Summary Get salary statistics
-// @Description Get total salaries by filters: month, year, and payment status
+// @Description Get total salaries by filters: month, quarter, year, and payment status
 // @Tags Salary
 // @Accept json
 // @Produce json
 // @Param month query int false "Month"
+// @Param quarter query int false "Quarter"
 // @Param year query int false "Year"
 // @Param status query string false "Status (Chưa thanh toán/Đã thanh toán)"
 // @Success 200 {object} map[string]float64 "Statistics"
@@ -262,46 +466,17 @@ func PaySalary(c *gin.Context) {
 // @Router /api/v1/salaries/stats [get]
 func GetSalaryStatistics(c *gin.Context) {
 	// Get query parameters for filters
-	month := c.DefaultQuery("month", "")
-	year := c.DefaultQuery("year", "")
-	status := c.DefaultQuery("status", "")
-
-	var totalSalaries float64
-	query := config.GetDB().Model(&models.Salary{})
-
-	// Apply filters based on query parameters
-	if month != "" {
-		monthInt, err := strconv.Atoi(month)
-		if err == nil {
-			// PostgreSQL: EXTRACT(MONTH FROM created_at)
-			query = query.Where("EXTRACT(MONTH FROM created_at) = ?", monthInt)
-		} else {
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid month format"})
-			return
-		}
+	filter, err := parseSalaryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
 	}
 
-	if year != "" {
-		yearInt, err := strconv.Atoi(year)
-		if err == nil {
-			// PostgreSQL: EXTRACT(YEAR FROM created_at)
-			query = query.Where("EXTRACT(YEAR FROM created_at) = ?", yearInt)
-		} else {
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid year format"})
-			return
-		}
-	}
-	if status != "" {
-		// Ensure status is either 'Chưa thanh toán' or 'Đã thanh toán'
-		if status != "Chưa thanh toán" && status != "Đã thanh toán" {
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid status. Must be 'Chưa thanh toán' or 'Đã thanh toán'"})
-			return
-		}
-		query = query.Where("status = ?", status)
-	}
+	var totalSalaries float64
+	query := filter.apply(config.GetDB().Model(&models.Salary{}))
 
 	// Calculate total salaries with the applied filters
-	if err := query.Select("SUM(total_salary)").Scan(&totalSalaries).Error; err != nil {
+	if err := query.Select("COALESCE(SUM(total_salary), 0)").Scan(&totalSalaries).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to calculate salary statistics"})
 		return
 	}
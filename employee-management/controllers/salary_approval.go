@@ -0,0 +1,259 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// salaryTransition mô tả một bước chuyển trạng thái phê duyệt hợp lệ
+type salaryTransition struct {
+	from  string
+	to    string
+	roles []string
+}
+
+// salaryTransitions liệt kê các bước tiến trong chuỗi phê duyệt
+var salaryTransitions = map[string]salaryTransition{
+	"prepare": {from: models.SalaryDraft, to: models.SalaryPrepared, roles: payrollRoles},
+	"review":  {from: models.SalaryPrepared, to: models.SalaryReviewed, roles: hrManagerRoles},
+	"approve": {from: models.SalaryReviewed, to: models.SalaryApproved, roles: financeRoles},
+}
+
+// errSalaryChanged được trả về khi trạng thái bảng lương thay đổi giữa lúc đọc và ghi
+var errSalaryChanged = errors.New("salary status changed concurrently")
+
+// SalaryApprovalRequest là dữ liệu gửi kèm khi chuyển bước phê duyệt
+type SalaryApprovalRequest struct {
+	Comment string `json:"comment"`
+}
+
+// recordSalaryApproval lưu lịch sử một lần chuyển trạng thái của bảng lương
+func recordSalaryApproval(tx *gorm.DB, salaryID uint, action, from, to string, actor models.Employee, comment string) error {
+	return tx.Create(&models.SalaryApproval{
+		SalaryID:   salaryID,
+		Action:     action,
+		FromStatus: from,
+		ToStatus:   to,
+		ActorID:    actor.ID,
+		ActorName:  actor.Name,
+		Comment:    comment,
+	}).Error
+}
+
+// markSalaryPaid chuyển bảng lương sang "Đã thanh toán" và ghi lại người thực hiện
+func markSalaryPaid(tx *gorm.DB, salary *models.Salary, actor models.Employee, comment string) error {
+	from := salary.Status
+	now := time.Now()
+	if err := tx.Model(salary).Updates(map[string]interface{}{
+		"status":  models.SalaryPaid,
+		"paid_at": now,
+	}).Error; err != nil {
+		return err
+	}
+	salary.Status = models.SalaryPaid
+	salary.PaidAt = &now
+	return recordSalaryApproval(tx, salary.ID, "pay", from, salary.Status, actor, comment)
+}
+
+// changeSalaryApproval thực hiện một bước phê duyệt (prepare/review/approve/reject)
+func changeSalaryApproval(c *gin.Context, action string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid salary ID"})
+		return
+	}
+
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	// Body là tùy chọn, chỉ chứa ghi chú của người duyệt
+	var request SalaryApprovalRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	var salary models.Salary
+	if err := config.GetDB().First(&salary, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Salary not found"})
+		return
+	}
+
+	from := salary.ApprovalStatus
+	var to string
+	var roles []string
+
+	if action == "reject" {
+		// Từ chối luôn đưa bảng lương về trạng thái nháp
+		if request.Comment == "" {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "A comment is required when rejecting a salary"})
+			return
+		}
+		switch from {
+		case models.SalaryPrepared:
+			roles = hrManagerRoles
+		case models.SalaryReviewed:
+			roles = financeRoles
+		default:
+			c.JSON(http.StatusConflict, ErrorResponse{Error: fmt.Sprintf("Cannot reject a salary in status '%s'", from)})
+			return
+		}
+		to = models.SalaryDraft
+	} else {
+		transition := salaryTransitions[action]
+		if from != transition.from {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: fmt.Sprintf("Salary must be '%s' to %s, current status is '%s'", transition.from, action, from)})
+			return
+		}
+		to = transition.to
+		roles = transition.roles
+	}
+
+	if !hasRole(actor, roles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: fmt.Sprintf("Role '%s' is not allowed to %s this salary", actor.Role, action)})
+		return
+	}
+
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		// Chỉ cập nhật nếu trạng thái chưa bị thay đổi bởi yêu cầu khác
+		result := tx.Model(&models.Salary{}).
+			Where("id = ? AND approval_status = ?", salary.ID, from).
+			Update("approval_status", to)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errSalaryChanged
+		}
+		return recordSalaryApproval(tx, salary.ID, action, from, to, actor, request.Comment)
+	})
+	if errors.Is(err, errSalaryChanged) {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Salary was modified concurrently, please retry"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update salary approval"})
+		return
+	}
+
+	salary.ApprovalStatus = to
+	c.JSON(http.StatusOK, salary)
+}
+
+// PrepareSalary godoc
+// @Summary Submit a salary for review
+// @Description Payroll marks a draft salary as prepared
+// @Tags Salary
+// @Accept json
+// @Produce json
+// @Param id path int true "Salary ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Comment"
+// @Success 200 {object} models.Salary
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/salaries/{id}/prepare [post]
+func PrepareSalary(c *gin.Context) {
+	changeSalaryApproval(c, "prepare")
+}
+
+// ReviewSalary godoc
+// @Summary Review a prepared salary
+// @Description HR manager marks a prepared salary as reviewed
+// @Tags Salary
+// @Accept json
+// @Produce json
+// @Param id path int true "Salary ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Comment"
+// @Success 200 {object} models.Salary
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/salaries/{id}/review [post]
+func ReviewSalary(c *gin.Context) {
+	changeSalaryApproval(c, "review")
+}
+
+// ApproveSalary godoc
+// @Summary Approve a reviewed salary
+// @Description Finance approves a reviewed salary so it can be paid
+// @Tags Salary
+// @Accept json
+// @Produce json
+// @Param id path int true "Salary ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Comment"
+// @Success 200 {object} models.Salary
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/salaries/{id}/approve [post]
+func ApproveSalary(c *gin.Context) {
+	changeSalaryApproval(c, "approve")
+}
+
+// RejectSalary godoc
+// @Summary Reject a salary back to draft
+// @Description The reviewer of the current step sends the salary back to draft with a comment
+// @Tags Salary
+// @Accept json
+// @Produce json
+// @Param id path int true "Salary ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest true "Rejection reason"
+// @Success 200 {object} models.Salary
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/salaries/{id}/reject [post]
+func RejectSalary(c *gin.Context) {
+	changeSalaryApproval(c, "reject")
+}
+
+// GetSalaryApprovals godoc
+// @Summary Get salary approval history
+// @Description List every approval transition of a salary in chronological order
+// @Tags Salary
+// @Accept json
+// @Produce json
+// @Param id path int true "Salary ID"
+// @Success 200 {array} models.SalaryApproval
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/salaries/{id}/approvals [get]
+func GetSalaryApprovals(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid salary ID"})
+		return
+	}
+
+	var approvals []models.SalaryApproval
+	if err := config.GetDB().Where("salary_id = ?", id).Order("created_at, id").Find(&approvals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch salary approvals"})
+		return
+	}
+
+	c.JSON(http.StatusOK, approvals)
+}
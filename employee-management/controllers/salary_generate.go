@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"employee-management/payroll"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GenerateSalariesRequest là dữ liệu để lập bảng lương hàng loạt cho một kỳ
+type GenerateSalariesRequest struct {
+	PeriodYear  int    `json:"period_year" binding:"required"`
+	PeriodMonth int    `json:"period_month" binding:"required"`
+	EmployeeIDs []uint `json:"employee_ids"` // Để trống để lập cho tất cả nhân viên
+}
+
+// GenerateSalariesResult liệt kê các bảng lương đã lập và nhân viên bị bỏ qua
+type GenerateSalariesResult struct {
+	Created []models.Salary     `json:"created"`
+	Skipped []GenerateSkipEntry `json:"skipped"`
+}
+
+// GenerateSkipEntry là lý do không lập bảng lương cho một nhân viên
+type GenerateSkipEntry struct {
+	EmployeeID uint   `json:"employee_id"`
+	Reason     string `json:"reason"`
+}
+
+// GenerateSalaries godoc
+// @Summary Generate draft salaries for a pay period
+// @Description Create draft regular salaries using the base salary effective in the period, pro-rated when it changes mid-month, working days from the timesheet, overtime earnings lines from recorded overtime, salary advance installments and approved expense reimbursements as non-taxable earnings lines
+// @Tags Salary
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body GenerateSalariesRequest true "Pay period and optional employee IDs"
+// @Success 201 {object} GenerateSalariesResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/salaries/generate [post]
+func GenerateSalaries(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, payrollRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only payroll can generate salaries"})
+		return
+	}
+
+	var request GenerateSalariesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	// Dùng normalizeSalaryPeriod để kiểm tra kỳ và tính ngày đầu/cuối kỳ
+	template := models.Salary{PeriodYear: request.PeriodYear, PeriodMonth: request.PeriodMonth, Type: models.SalaryRegular}
+	if err := normalizeSalaryPeriod(&template); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var employees []models.Employee
+	query := config.GetDB()
+	if len(request.EmployeeIDs) > 0 {
+		query = query.Where("id IN ?", request.EmployeeIDs)
+	}
+	if err := query.Order("id").Find(&employees).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch employees"})
+		return
+	}
+
+	result := GenerateSalariesResult{Created: []models.Salary{}, Skipped: []GenerateSkipEntry{}}
+	for _, employee := range employees {
+		salary := template
+		salary.EmployeeID = employee.ID
+		salary.EmployeeName = employee.Name
+
+		if exists, err := regularSalaryExists(salary); err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check existing salaries"})
+			return
+		} else if exists {
+			result.Skipped = append(result.Skipped, GenerateSkipEntry{EmployeeID: employee.ID, Reason: "Regular salary already exists for this period"})
+			continue
+		}
+
+		// Lấy mức lương có hiệu lực trong kỳ, tính theo tỷ lệ nếu thay đổi giữa kỳ
+		rates, err := approvedRates(employee.ID, salary.PeriodEnd.Time)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch compensation history"})
+			return
+		}
+		basic, ok := payroll.ProratedBaseSalary(rates, salary.PeriodStart.Time, salary.PeriodEnd.Time)
+		if !ok {
+			result.Skipped = append(result.Skipped, GenerateSkipEntry{EmployeeID: employee.ID, Reason: "No approved base salary effective in this period"})
+			continue
+		}
+
+		// Số ngày công lấy từ bảng chấm công của kỳ
+		timesheet, err := buildTimesheet(employee.ID, salary.PeriodStart.Time, salary.PeriodEnd.Time)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to build timesheet"})
+			return
+		}
+
+		salary.BasicSalary = basic
+		salary.Coefficient = standardWorkingDays()
+		salary.WorkingDays = timesheet.WorkingDays
+		salary.Status = models.SalaryUnpaid
+		salary.ApprovalStatus = models.SalaryDraft
+
+		// Giờ làm thêm đã ghi nhận trong kỳ được trả theo đơn giá giờ của lương cơ bản
+		hourlyRate := float64(basic) / float64(salary.Coefficient) / standardHoursPerDay()
+		lines, entryIDs, overtimePay, err := overtimeEarnings(employee.ID, salary.PeriodStart.Time, salary.PeriodEnd.Time, hourlyRate)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to calculate overtime"})
+			return
+		}
+		salary.OvertimePay = overtimePay
+		salary.EarningLines = lines
+		calculateTotalSalary(&salary)
+
+		// Trừ tạm ứng theo kỳ trả góp, không vượt quá số lương còn lại
+		repayments, deduction, err := advanceDeductions(employee.ID, salary.PeriodYear, salary.PeriodMonth, salary.TotalSalary)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to calculate advance deductions"})
+			return
+		}
+		salary.AdvanceDeduction = deduction
+
+		// Chi phí công tác đã duyệt được hoàn trả trên bảng lương kế tiếp, không bị trừ tạm ứng
+		reimbursementLines, claimIDs, reimbursement, err := reimbursementEarnings(employee.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to calculate expense reimbursements"})
+			return
+		}
+		salary.Reimbursement = reimbursement
+		salary.EarningLines = append(salary.EarningLines, reimbursementLines...)
+		calculateTotalSalary(&salary)
+
+		err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&salary).Error; err != nil {
+				return err
+			}
+			if err := applyAdvanceRepayments(tx, salary.ID, repayments); err != nil {
+				return err
+			}
+			if err := applyReimbursements(tx, salary.ID, claimIDs); err != nil {
+				return err
+			}
+			if len(entryIDs) == 0 {
+				return nil
+			}
+			return tx.Model(&models.OvertimeEntry{}).Where("id IN ?", entryIDs).Update("salary_id", salary.ID).Error
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create salary"})
+			return
+		}
+		result.Created = append(result.Created, salary)
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,300 @@
+package controllers
+
+import (
+	"employee-management/bankfile"
+	"employee-management/config"
+	"employee-management/models"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// paymentReference tạo mã tham chiếu chuyển khoản cho một bảng lương
+func paymentReference(salaryID uint) string {
+	return fmt.Sprintf("SAL%08d", salaryID)
+}
+
+// parseIDList đọc danh sách ID dạng "1,2,3" từ query string
+func parseIDList(value string) ([]uint, error) {
+	var ids []uint
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q", part)
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+// RejectedSalaries liệt kê các bảng lương không thể đưa vào file chuyển khoản,
+// ví dụ nhân viên chưa khai báo tài khoản ngân hàng hoặc tổng lương âm
+type RejectedSalaries struct {
+	Error     string `json:"error"`
+	SalaryIDs []uint `json:"salary_ids"`
+}
+
+// GenerateBankTransferFile godoc
+// @Summary Generate a bank bulk transfer file
+// @Description Build a bulk payment file for approved, unpaid salaries in the requested bank format, skipping salaries with nothing to pay and rejecting negative totals, and record each salary's payment reference for reconciliation. Values that do not fit a fixed-width column are rejected rather than cut.
+// @Tags Salary
+// @Produce plain
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param format query string false "File format (csv, vcb, bidv)" default(csv)
+// @Param ids query string false "Comma separated salary IDs, defaults to every approved unpaid salary"
+// @Param value_date query string false "Value date (YYYY-MM-DD), defaults to today"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} RejectedSalaries
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/salaries/bank-transfer [post]
+func GenerateBankTransferFile(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, financeRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only finance can generate bank transfer files"})
+		return
+	}
+
+	format, err := bankfile.Lookup(c.DefaultQuery("format", "csv"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("%v, supported formats: %s", err, strings.Join(bankfile.Names(), ", "))})
+		return
+	}
+
+	valueDate := time.Now()
+	if value := c.Query("value_date"); value != "" {
+		valueDate, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid value_date format, expected YYYY-MM-DD"})
+			return
+		}
+	}
+
+	query := config.GetDB().Preload("Employee").
+		Where("approval_status = ? AND status = ?", models.SalaryApproved, models.SalaryUnpaid)
+	if value := c.Query("ids"); value != "" {
+		ids, err := parseIDList(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+		query = query.Where("id IN ?", ids)
+	}
+
+	var salaries []models.Salary
+	if err := query.Order("id").Find(&salaries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch salaries"})
+		return
+	}
+	if len(salaries) == 0 {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "No approved unpaid salaries to transfer"})
+		return
+	}
+
+	// Bảng lương không còn gì để trả (ví dụ khoản trừ tạm ứng bằng tổng lương) không cần chuyển khoản,
+	// tổng lương âm là dữ liệu sai nên không tạo file
+	var negative []uint
+	transfers := salaries[:0]
+	for _, salary := range salaries {
+		switch {
+		case salary.TotalSalary < 0:
+			negative = append(negative, salary.ID)
+		case salary.TotalSalary > 0:
+			transfers = append(transfers, salary)
+		}
+	}
+	if len(negative) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, RejectedSalaries{Error: "Some salaries have a negative total", SalaryIDs: negative})
+		return
+	}
+	salaries = transfers
+	if len(salaries) == 0 {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "No approved unpaid salaries with an amount to transfer"})
+		return
+	}
+
+	// Không tạo file nếu có nhân viên thiếu thông tin tài khoản, tránh chuyển khoản sai
+	var missing []uint
+	for _, salary := range salaries {
+		e := salary.Employee
+		if e.BankCode == "" || e.BankAccountNumber == "" || e.BankAccountHolder == "" {
+			missing = append(missing, salary.ID)
+		}
+	}
+	if len(missing) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, RejectedSalaries{Error: "Some employees have no bank account details", SalaryIDs: missing})
+		return
+	}
+
+	batch := bankfile.Batch{
+		Reference:    fmt.Sprintf("PAY%s", time.Now().Format("060102150405")),
+		DebitAccount: config.GetEnv("COMPANY_BANK_ACCOUNT"),
+		ValueDate:    valueDate,
+	}
+	for _, salary := range salaries {
+		batch.Records = append(batch.Records, bankfile.Record{
+			Reference:     paymentReference(salary.ID),
+			BankCode:      salary.Employee.BankCode,
+			AccountNumber: salary.Employee.BankAccountNumber,
+			AccountHolder: salary.Employee.BankAccountHolder,
+			Amount:        int64(salary.TotalSalary),
+			// Mã tham chiếu đặt đầu ghi chú để không bị cắt khi tên nhân viên dài
+			Description: fmt.Sprintf("%s Luong %02d/%d %s", paymentReference(salary.ID), salary.PeriodMonth, salary.PeriodYear, salary.EmployeeName),
+		})
+	}
+
+	var content strings.Builder
+	err = format.Write(&content, batch)
+	if errors.Is(err, bankfile.ErrFieldOverflow) {
+		// Dữ liệu nhân viên không vừa cột của ngân hàng, cần sửa thông tin tài khoản
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: fmt.Sprintf("Failed to generate bank file: %v", err)})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to generate bank file: %v", err)})
+		return
+	}
+
+	// Lưu mã tham chiếu để đối soát khi ngân hàng trả kết quả
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		for _, salary := range salaries {
+			if err := tx.Model(&salary).Update("payment_reference", paymentReference(salary.ID)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to save payment references"})
+		return
+	}
+
+	filename := fmt.Sprintf("%s.%s", batch.Reference, format.Extension())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, format.ContentType(), []byte(content.String()))
+}
+
+// ReconcileIssue mô tả một dòng kết quả không được đánh dấu đã thanh toán
+type ReconcileIssue struct {
+	Reference string `json:"reference"`
+	Reason    string `json:"reason"`
+}
+
+// ReconcileSummary là kết quả đối soát file ngân hàng
+type ReconcileSummary struct {
+	Paid    []uint           `json:"paid"`
+	Skipped []ReconcileIssue `json:"skipped"`
+}
+
+// ReconcileBankTransfer godoc
+// @Summary Reconcile a bank result file
+// @Description Import the bank's result file and mark successfully transferred salaries as paid
+// @Tags Salary
+// @Accept mpfd
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param format query string false "File format (csv, vcb, bidv)" default(csv)
+// @Param file formData file true "Bank result file"
+// @Success 200 {object} ReconcileSummary
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/salaries/bank-transfer/reconcile [post]
+func ReconcileBankTransfer(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, financeRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only finance can reconcile bank transfers"})
+		return
+	}
+
+	format, err := bankfile.Lookup(c.DefaultQuery("format", "csv"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("%v, supported formats: %s", err, strings.Join(bankfile.Names(), ", "))})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Result file is required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Failed to read result file"})
+		return
+	}
+	defer file.Close()
+
+	results, err := format.ParseResults(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Invalid result file: %v", err)})
+		return
+	}
+
+	summary := ReconcileSummary{Paid: []uint{}, Skipped: []ReconcileIssue{}}
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		for _, result := range results {
+			if !result.Success {
+				summary.Skipped = append(summary.Skipped, ReconcileIssue{Reference: result.Reference, Reason: "Transfer failed: " + result.Message})
+				continue
+			}
+
+			var salary models.Salary
+			if err := tx.Where("payment_reference = ?", result.Reference).First(&salary).Error; err != nil {
+				summary.Skipped = append(summary.Skipped, ReconcileIssue{Reference: result.Reference, Reason: "No salary with this reference"})
+				continue
+			}
+			if salary.Status == models.SalaryPaid {
+				summary.Skipped = append(summary.Skipped, ReconcileIssue{Reference: result.Reference, Reason: "Salary already paid"})
+				continue
+			}
+			if salary.ApprovalStatus != models.SalaryApproved {
+				summary.Skipped = append(summary.Skipped, ReconcileIssue{Reference: result.Reference, Reason: "Salary is not approved"})
+				continue
+			}
+			if int64(salary.TotalSalary) != result.Amount {
+				summary.Skipped = append(summary.Skipped, ReconcileIssue{
+					Reference: result.Reference,
+					Reason:    fmt.Sprintf("Amount mismatch: expected %d, bank reported %d", salary.TotalSalary, result.Amount),
+				})
+				continue
+			}
+
+			comment := "Bank reconciliation"
+			if result.TransactionID != "" {
+				comment += ", transaction " + result.TransactionID
+			}
+			if err := markSalaryPaid(tx, &salary, actor, comment); err != nil {
+				return err
+			}
+			summary.Paid = append(summary.Paid, salary.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to reconcile bank transfer"})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
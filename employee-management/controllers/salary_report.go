@@ -0,0 +1,357 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SalaryGroupStats là thống kê lương của một nhóm (phòng ban, chức vụ hoặc kỳ)
+type SalaryGroupStats struct {
+	Key       string             `json:"key"`
+	Label     string             `json:"label"`
+	Headcount int                `json:"headcount"`
+	Count     int                `json:"count"`
+	Sum       int64              `json:"sum"`
+	Average   float64            `json:"average"`
+	Median    float64            `json:"median"`
+	Min       int                `json:"min"`
+	Max       int                `json:"max"`
+	Change    *SalaryStatsChange `json:"change,omitempty"`
+}
+
+// SalaryStatsChange so sánh một nhóm với cùng nhóm ở kỳ liền trước
+type SalaryStatsChange struct {
+	PreviousKey      string   `json:"previous_key"`
+	PreviousSum      int64    `json:"previous_sum"`
+	SumChange        int64    `json:"sum_change"`
+	SumChangePercent *float64 `json:"sum_change_percent"`
+	AverageChange    float64  `json:"average_change"`
+	HeadcountChange  int      `json:"headcount_change"`
+}
+
+// SalaryReport là kết quả báo cáo thống kê lương
+type SalaryReport struct {
+	GroupBy        string             `json:"group_by"`
+	PreviousPeriod string             `json:"previous_period,omitempty"`
+	Total          SalaryGroupStats   `json:"total"`
+	Groups         []SalaryGroupStats `json:"groups"`
+}
+
+// salaryReportRow là một dòng lương dùng để tổng hợp báo cáo
+type salaryReportRow struct {
+	ID          uint
+	EmployeeID  uint
+	TotalSalary int
+	PeriodYear  int
+	PeriodMonth int
+}
+
+// salaryGroup là một nhóm (ID, tên) mà một bảng lương thuộc về
+type salaryGroup struct {
+	Key   string
+	Label string
+}
+
+// previous trả về bộ lọc của kỳ liền trước, dùng để so sánh theo phòng ban/chức vụ
+func (f salaryFilter) previous() (salaryFilter, bool) {
+	prev := f
+	switch {
+	case f.Year != 0 && f.Month != 0:
+		prev.Month--
+		if prev.Month == 0 {
+			prev.Month = 12
+			prev.Year--
+		}
+	case f.Year != 0 && f.Quarter != 0:
+		prev.Quarter--
+		if prev.Quarter == 0 {
+			prev.Quarter = 4
+			prev.Year--
+		}
+	case f.Year != 0:
+		prev.Year--
+	default:
+		return prev, false
+	}
+	return prev, true
+}
+
+// label mô tả kỳ của bộ lọc, ví dụ "2024-03", "2024-Q1", "2024"
+func (f salaryFilter) label() string {
+	switch {
+	case f.Month != 0:
+		return fmt.Sprintf("%04d-%02d", f.Year, f.Month)
+	case f.Quarter != 0:
+		return fmt.Sprintf("%04d-Q%d", f.Year, f.Quarter)
+	default:
+		return strconv.Itoa(f.Year)
+	}
+}
+
+// loadSalaryReportRows lấy các dòng lương khớp bộ lọc
+func loadSalaryReportRows(filter salaryFilter) ([]salaryReportRow, error) {
+	var rows []salaryReportRow
+	err := filter.apply(config.GetDB().Model(&models.Salary{})).
+		Select("salaries.id, salaries.employee_id, salaries.total_salary, salaries.period_year, salaries.period_month").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// membershipGroups trả về danh sách phòng ban hoặc chức vụ của từng nhân viên
+func membershipGroups(groupBy string, employeeIDs []uint) (map[uint][]salaryGroup, error) {
+	var memberships []struct {
+		EmployeeID uint
+		GroupID    uint
+		Name       string
+	}
+
+	query := config.GetDB()
+	if groupBy == "department" {
+		query = query.Table("employee_departments").
+			Select("employee_departments.employee_id, departments.id AS group_id, departments.name").
+			Joins("JOIN departments ON departments.id = employee_departments.department_id").
+			Where("employee_departments.employee_id IN ?", employeeIDs)
+		query = activeDepartments(query)
+	} else {
+		query = query.Table("employee_positions").
+			Select("employee_positions.employee_id, positions.id AS group_id, positions.title AS name").
+			Joins("JOIN positions ON positions.id = employee_positions.position_id").
+			Where("employee_positions.employee_id IN ?", employeeIDs)
+		query = activePositions(query)
+	}
+	if err := query.Scan(&memberships).Error; err != nil {
+		return nil, err
+	}
+
+	groups := map[uint][]salaryGroup{}
+	for _, m := range memberships {
+		groups[m.EmployeeID] = append(groups[m.EmployeeID], salaryGroup{Key: strconv.Itoa(int(m.GroupID)), Label: m.Name})
+	}
+	return groups, nil
+}
+
+// periodGroup trả về nhóm thời gian theo kỳ lương của một bảng lương
+func periodGroup(groupBy string, row salaryReportRow) salaryGroup {
+	var key string
+	switch groupBy {
+	case "month":
+		key = fmt.Sprintf("%04d-%02d", row.PeriodYear, row.PeriodMonth)
+	case "quarter":
+		key = fmt.Sprintf("%04d-Q%d", row.PeriodYear, (row.PeriodMonth-1)/3+1)
+	default:
+		key = strconv.Itoa(row.PeriodYear)
+	}
+	return salaryGroup{Key: key, Label: key}
+}
+
+// previousPeriodKey trả về khóa của kỳ liền trước một nhóm thời gian
+func previousPeriodKey(groupBy, key string) string {
+	switch groupBy {
+	case "month":
+		t, err := time.Parse("2006-01", key)
+		if err != nil {
+			return ""
+		}
+		return t.AddDate(0, -1, 0).Format("2006-01")
+	case "quarter":
+		var year, quarter int
+		if _, err := fmt.Sscanf(key, "%d-Q%d", &year, &quarter); err != nil {
+			return ""
+		}
+		if quarter == 1 {
+			return fmt.Sprintf("%04d-Q4", year-1)
+		}
+		return fmt.Sprintf("%04d-Q%d", year, quarter-1)
+	default:
+		year, err := strconv.Atoi(key)
+		if err != nil {
+			return ""
+		}
+		return strconv.Itoa(year - 1)
+	}
+}
+
+// groupSalaryRows chia các dòng lương theo nhóm và tính thống kê cho từng nhóm
+func groupSalaryRows(groupBy string, rows []salaryReportRow) (map[string]SalaryGroupStats, error) {
+	var memberships map[uint][]salaryGroup
+	if groupBy == "department" || groupBy == "position" {
+		employeeIDs := make([]uint, 0, len(rows))
+		for _, row := range rows {
+			employeeIDs = append(employeeIDs, row.EmployeeID)
+		}
+		var err error
+		if memberships, err = membershipGroups(groupBy, employeeIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	labels := map[string]string{}
+	buckets := map[string][]salaryReportRow{}
+	for _, row := range rows {
+		var groups []salaryGroup
+		if memberships != nil {
+			groups = memberships[row.EmployeeID]
+			if len(groups) == 0 {
+				groups = []salaryGroup{{Key: "0", Label: "Không xác định"}}
+			}
+		} else {
+			groups = []salaryGroup{periodGroup(groupBy, row)}
+		}
+		// Nhân viên thuộc nhiều phòng ban/chức vụ được tính trong từng nhóm
+		for _, g := range groups {
+			labels[g.Key] = g.Label
+			buckets[g.Key] = append(buckets[g.Key], row)
+		}
+	}
+
+	stats := map[string]SalaryGroupStats{}
+	for key, bucket := range buckets {
+		s := summarizeSalaries(bucket)
+		s.Key = key
+		s.Label = labels[key]
+		stats[key] = s
+	}
+	return stats, nil
+}
+
+// summarizeSalaries tính tổng, trung bình, trung vị, min/max và số nhân viên
+func summarizeSalaries(rows []salaryReportRow) SalaryGroupStats {
+	var stats SalaryGroupStats
+	if len(rows) == 0 {
+		return stats
+	}
+
+	amounts := make([]int, 0, len(rows))
+	employees := map[uint]bool{}
+	for _, row := range rows {
+		amounts = append(amounts, row.TotalSalary)
+		employees[row.EmployeeID] = true
+		stats.Sum += int64(row.TotalSalary)
+	}
+	sort.Ints(amounts)
+
+	stats.Count = len(amounts)
+	stats.Headcount = len(employees)
+	stats.Min = amounts[0]
+	stats.Max = amounts[len(amounts)-1]
+	stats.Average = float64(stats.Sum) / float64(stats.Count)
+	if mid := len(amounts) / 2; len(amounts)%2 == 1 {
+		stats.Median = float64(amounts[mid])
+	} else {
+		stats.Median = float64(amounts[mid-1]+amounts[mid]) / 2
+	}
+	return stats
+}
+
+// compareSalaryStats tính mức thay đổi so với kỳ trước
+func compareSalaryStats(current, previous SalaryGroupStats, previousKey string) *SalaryStatsChange {
+	change := &SalaryStatsChange{
+		PreviousKey:     previousKey,
+		PreviousSum:     previous.Sum,
+		SumChange:       current.Sum - previous.Sum,
+		AverageChange:   current.Average - previous.Average,
+		HeadcountChange: current.Headcount - previous.Headcount,
+	}
+	if previous.Sum != 0 {
+		percent := float64(change.SumChange) / float64(previous.Sum) * 100
+		change.SumChangePercent = &percent
+	}
+	return change
+}
+
+// GetSalaryReport godoc
+// @Summary Get salary statistics grouped by department, position or period
+// @Description Sum, average, median, min/max and headcount per group with period-over-period changes
+// @Tags Salary
+// @Accept json
+// @Produce json
+// @Param group_by query string true "Grouping (department, position, month, quarter, year)"
+// @Param month query int false "Month"
+// @Param quarter query int false "Quarter"
+// @Param year query int false "Year"
+// @Param status query string false "Status (Chưa thanh toán/Đã thanh toán)"
+// @Success 200 {object} SalaryReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/salaries/report [get]
+func GetSalaryReport(c *gin.Context) {
+	groupBy := c.Query("group_by")
+	switch groupBy {
+	case "department", "position", "month", "quarter", "year":
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid group_by. Must be one of department, position, month, quarter, year"})
+		return
+	}
+
+	filter, err := parseSalaryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	rows, err := loadSalaryReportRows(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch salaries"})
+		return
+	}
+	current, err := groupSalaryRows(groupBy, rows)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to group salaries"})
+		return
+	}
+
+	report := SalaryReport{GroupBy: groupBy, Total: summarizeSalaries(rows), Groups: []SalaryGroupStats{}}
+	report.Total.Key = "total"
+	report.Total.Label = "Tổng"
+
+	if groupBy == "department" || groupBy == "position" {
+		// So sánh từng phòng ban/chức vụ với cùng nhóm ở kỳ liền trước
+		if prevFilter, ok := filter.previous(); ok {
+			prevRows, err := loadSalaryReportRows(prevFilter)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch salaries"})
+				return
+			}
+			previous, err := groupSalaryRows(groupBy, prevRows)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to group salaries"})
+				return
+			}
+			report.PreviousPeriod = prevFilter.label()
+			for key, stats := range current {
+				stats.Change = compareSalaryStats(stats, previous[key], report.PreviousPeriod)
+				current[key] = stats
+			}
+			report.Total.Change = compareSalaryStats(report.Total, summarizeSalaries(prevRows), report.PreviousPeriod)
+		}
+	} else {
+		// So sánh mỗi kỳ với kỳ liền trước nếu kỳ đó nằm trong kết quả
+		for key, stats := range current {
+			prevKey := previousPeriodKey(groupBy, key)
+			if previous, ok := current[prevKey]; ok {
+				stats.Change = compareSalaryStats(stats, previous, prevKey)
+				current[key] = stats
+			}
+		}
+	}
+
+	for _, stats := range current {
+		report.Groups = append(report.Groups, stats)
+	}
+	// Kỳ được sắp theo thời gian, phòng ban/chức vụ được sắp theo tên
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if groupBy == "department" || groupBy == "position" {
+			return report.Groups[i].Label < report.Groups[j].Label
+		}
+		return report.Groups[i].Key < report.Groups[j].Key
+	})
+
+	c.JSON(http.StatusOK, report)
+}
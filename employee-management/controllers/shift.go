@@ -0,0 +1,775 @@
+package controllers
+
+import (
+	"employee-management/calendar"
+	"employee-management/config"
+	"employee-management/models"
+	"employee-management/payroll"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ConflictResponse là lỗi kèm danh sách lịch bị trùng
+type ConflictResponse struct {
+	Error     string             `json:"error"`
+	Conflicts []ScheduleConflict `json:"conflicts"`
+}
+
+// RosterEntry là một dòng xếp ca trong lịch tuần
+type RosterEntry struct {
+	EmployeeID      uint              `json:"employee_id" binding:"required"`
+	Date            models.CustomTime `json:"date" binding:"required"`
+	ShiftTemplateID uint              `json:"shift_template_id" binding:"required"`
+}
+
+// RosterInput là lịch ca tuần gửi lên để thay thế lịch hiện tại của phòng ban
+type RosterInput struct {
+	Entries []RosterEntry `json:"entries"`
+}
+
+// Roster là lịch ca của phòng ban trong một tuần
+type Roster struct {
+	DepartmentID uint                     `json:"department_id"`
+	WeekStart    string                   `json:"week_start"`
+	WeekEnd      string                   `json:"week_end"`
+	Assignments  []models.ShiftAssignment `json:"assignments"`
+}
+
+// ShiftSwapInput là dữ liệu nhân viên gửi khi đề nghị đổi ca
+type ShiftSwapInput struct {
+	RequesterAssignmentID uint   `json:"requester_assignment_id" binding:"required"`
+	TargetAssignmentID    uint   `json:"target_assignment_id" binding:"required"`
+	Reason                string `json:"reason"`
+}
+
+var errShiftChanged = errors.New("shift assignments changed since the swap was requested")
+
+// validateShiftTemplate kiểm tra giờ ca và thời gian nghỉ hợp lệ
+func validateShiftTemplate(template models.ShiftTemplate) error {
+	_, err := payroll.ShiftDuration(template.StartTime, template.EndTime, template.BreakMinutes)
+	return err
+}
+
+// parseWeekStart đọc ngày đầu tuần từ query week_start, mặc định là thứ Hai của tuần hiện tại
+func parseWeekStart(c *gin.Context) (time.Time, bool) {
+	if value := c.Query("week_start"); value != "" {
+		start, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid week_start format, expected YYYY-MM-DD"})
+			return start, false
+		}
+		return start, true
+	}
+
+	today := dateOnly(time.Now())
+	offset := (int(today.Weekday()) + 6) % 7
+	return today.AddDate(0, 0, -offset), true
+}
+
+// loadRoster lấy các ca của phòng ban trong khoảng [from, to]
+func loadRoster(departmentID uint, from, to time.Time) ([]models.ShiftAssignment, error) {
+	var assignments []models.ShiftAssignment
+	err := config.GetDB().Preload("ShiftTemplate").
+		Where("department_id = ? AND date BETWEEN ? AND ?", departmentID, calendar.DateKey(from), calendar.DateKey(to)).
+		Order("date, employee_id").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// GetShiftTemplates godoc
+// @Summary Get shift templates
+// @Description List the shift templates used to build rosters
+// @Tags Shift
+// @Produce json
+// @Success 200 {array} models.ShiftTemplate
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shifts [get]
+func GetShiftTemplates(c *gin.Context) {
+	var templates []models.ShiftTemplate
+	if err := config.GetDB().Order("start_time, name").Find(&templates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch shift templates"})
+		return
+	}
+	c.JSON(http.StatusOK, templates)
+}
+
+// CreateShiftTemplate godoc
+// @Summary Create a shift template
+// @Description Add a shift with start and end time (HH:MM), break minutes and night-shift flag; an end before the start means the shift runs overnight
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param shift body models.ShiftTemplate true "Shift template data"
+// @Success 201 {object} models.ShiftTemplate
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shifts [post]
+func CreateShiftTemplate(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, managerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can manage shift templates"})
+		return
+	}
+
+	var template models.ShiftTemplate
+	if err := c.ShouldBindJSON(&template); err != nil || template.Name == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	if err := validateShiftTemplate(template); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var existing models.ShiftTemplate
+	if err := config.GetDB().Where("name = ?", template.Name).First(&existing).Error; err == nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Shift template with this name already exists"})
+		return
+	}
+
+	if err := config.GetDB().Create(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create shift template"})
+		return
+	}
+	c.JSON(http.StatusCreated, template)
+}
+
+// UpdateShiftTemplate godoc
+// @Summary Update a shift template
+// @Description Change the hours, break or night-shift flag of a shift template
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Param id path int true "Shift template ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param shift body models.ShiftTemplate true "Shift template data"
+// @Success 200 {object} models.ShiftTemplate
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shifts/{id} [put]
+func UpdateShiftTemplate(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, managerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can manage shift templates"})
+		return
+	}
+
+	var template models.ShiftTemplate
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&template).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Shift template not found"})
+		return
+	}
+
+	id := template.ID
+	if err := c.ShouldBindJSON(&template); err != nil || template.Name == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	template.ID = id
+	if err := validateShiftTemplate(template); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := config.GetDB().Save(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update shift template"})
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteShiftTemplate godoc
+// @Summary Delete a shift template
+// @Description Delete a shift template that is not used in any roster
+// @Tags Shift
+// @Param id path int true "Shift template ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {object} ResponseMessage
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shifts/{id} [delete]
+func DeleteShiftTemplate(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, managerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can manage shift templates"})
+		return
+	}
+
+	var template models.ShiftTemplate
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&template).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Shift template not found"})
+		return
+	}
+
+	var used int64
+	if err := config.GetDB().Model(&models.ShiftAssignment{}).Where("shift_template_id = ?", template.ID).Count(&used).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check shift usage"})
+		return
+	}
+	if used > 0 {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Shift template is used in rosters"})
+		return
+	}
+
+	if err := config.GetDB().Delete(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to delete shift template"})
+		return
+	}
+	c.JSON(http.StatusOK, ResponseMessage{Message: "Shift template deleted successfully"})
+}
+
+// GetDepartmentRoster godoc
+// @Summary Get a weekly roster
+// @Description Get the shifts of a department for the week starting at week_start (defaults to this week's Monday)
+// @Tags Shift
+// @Produce json
+// @Param department_id path int true "Department ID"
+// @Param week_start query string false "First day of the week (YYYY-MM-DD)"
+// @Success 200 {object} Roster
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/departments/{department_id}/roster [get]
+func GetDepartmentRoster(c *gin.Context) {
+	var department models.Department
+	if err := config.GetDB().Where("id = ?", c.Param("department_id")).First(&department).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Department not found"})
+		return
+	}
+
+	weekStart, ok := parseWeekStart(c)
+	if !ok {
+		return
+	}
+	weekEnd := weekStart.AddDate(0, 0, 6)
+
+	assignments, err := loadRoster(department.ID, weekStart, weekEnd)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch roster"})
+		return
+	}
+
+	c.JSON(http.StatusOK, Roster{
+		DepartmentID: department.ID,
+		WeekStart:    calendar.DateKey(weekStart),
+		WeekEnd:      calendar.DateKey(weekEnd),
+		Assignments:  assignments,
+	})
+}
+
+// SaveDepartmentRoster godoc
+// @Summary Save a weekly roster
+// @Description Replace the shifts of a department for the week starting at week_start. Entries that clash with approved leave, work assignments or shifts in other departments are rejected with the list of conflicts.
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param week_start query string false "First day of the week (YYYY-MM-DD)"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param roster body RosterInput true "Roster entries"
+// @Success 200 {object} Roster
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ConflictResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/departments/{id}/roster [put]
+func SaveDepartmentRoster(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	if !hasRole(actor, managerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can edit rosters"})
+		return
+	}
+
+	var department models.Department
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&department).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Department not found"})
+		return
+	}
+
+	weekStart, ok := parseWeekStart(c)
+	if !ok {
+		return
+	}
+	weekEnd := weekStart.AddDate(0, 0, 6)
+
+	var input RosterInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	// Ca hiện tại của phòng ban trong tuần sẽ bị thay thế nên không tính là trùng
+	current, err := loadRoster(department.ID, weekStart, weekEnd)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch roster"})
+		return
+	}
+	replaced := make([]uint, 0, len(current))
+	for _, assignment := range current {
+		replaced = append(replaced, assignment.ID)
+	}
+
+	templates := map[uint]bool{}
+	members := map[string]bool{} // Theo nhân viên và ngày, vì nhân viên có thể được điều chuyển trong tuần
+	seen := map[string]bool{}
+	assignments := make([]models.ShiftAssignment, 0, len(input.Entries))
+	conflicts := []ScheduleConflict{}
+
+	for _, entry := range input.Entries {
+		date := entry.Date.Time
+		key := calendar.DateKey(date)
+		if key < calendar.DateKey(weekStart) || key > calendar.DateKey(weekEnd) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Entry date " + key + " is outside the roster week"})
+			return
+		}
+
+		employeeKey := strconv.Itoa(int(entry.EmployeeID)) + "/" + key
+		if seen[employeeKey] {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Employee " + strconv.Itoa(int(entry.EmployeeID)) + " has more than one shift on " + key})
+			return
+		}
+		seen[employeeKey] = true
+
+		if _, checked := templates[entry.ShiftTemplateID]; !checked {
+			var count int64
+			if err := config.GetDB().Model(&models.ShiftTemplate{}).Where("id = ?", entry.ShiftTemplateID).Count(&count).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check shift template"})
+				return
+			}
+			templates[entry.ShiftTemplateID] = count > 0
+		}
+		if !templates[entry.ShiftTemplateID] {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Shift template " + strconv.Itoa(int(entry.ShiftTemplateID)) + " not found"})
+			return
+		}
+
+		memberKey := strconv.Itoa(int(entry.EmployeeID)) + "/" + key
+		if _, checked := members[memberKey]; !checked {
+			var count int64
+			if err := activeOn(config.GetDB().Model(&models.EmployeeDepartment{}), "employee_departments", date).
+				Where("employee_id = ? AND department_id = ?", entry.EmployeeID, department.ID).
+				Count(&count).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check department membership"})
+				return
+			}
+			members[memberKey] = count > 0
+		}
+		if !members[memberKey] {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Employee " + strconv.Itoa(int(entry.EmployeeID)) + " does not belong to this department"})
+			return
+		}
+
+		found, err := absenceConflicts(entry.EmployeeID, date, date)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check schedule conflicts"})
+			return
+		}
+		shifts, err := shiftConflicts(entry.EmployeeID, date, date, replaced...)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check schedule conflicts"})
+			return
+		}
+		conflicts = append(conflicts, append(found, shifts...)...)
+
+		assignments = append(assignments, models.ShiftAssignment{
+			DepartmentID:    department.ID,
+			EmployeeID:      entry.EmployeeID,
+			Date:            entry.Date,
+			ShiftTemplateID: entry.ShiftTemplateID,
+		})
+	}
+
+	if len(conflicts) > 0 {
+		c.JSON(http.StatusConflict, ConflictResponse{Error: "Roster conflicts with existing schedules", Conflicts: conflicts})
+		return
+	}
+
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		if len(replaced) > 0 {
+			if err := tx.Where("id IN ?", replaced).Delete(&models.ShiftAssignment{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(assignments) > 0 {
+			return tx.Create(&assignments).Error
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to save roster"})
+		return
+	}
+
+	saved, err := loadRoster(department.ID, weekStart, weekEnd)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch roster"})
+		return
+	}
+	c.JSON(http.StatusOK, Roster{
+		DepartmentID: department.ID,
+		WeekStart:    calendar.DateKey(weekStart),
+		WeekEnd:      calendar.DateKey(weekEnd),
+		Assignments:  saved,
+	})
+}
+
+// GetShiftSwapRequests godoc
+// @Summary Get shift swap requests
+// @Description List shift swap requests, optionally filtered by employee (requester or target) and status
+// @Tags Shift
+// @Produce json
+// @Param employee_id query int false "Employee ID"
+// @Param status query string false "Status (pending/approved/rejected)"
+// @Success 200 {array} models.ShiftSwapRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shift-swaps [get]
+func GetShiftSwapRequests(c *gin.Context) {
+	query := config.GetDB()
+
+	if employeeID := c.Query("employee_id"); employeeID != "" {
+		id, err := strconv.Atoi(employeeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+			return
+		}
+		query = query.Where("requester_id = ? OR target_employee_id = ?", id, id)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var requests []models.ShiftSwapRequest
+	if err := query.Order("id DESC").Find(&requests).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch shift swap requests"})
+		return
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+// CreateShiftSwapRequest godoc
+// @Summary Request a shift swap
+// @Description The current employee offers one of their shifts in exchange for a colleague's shift in the same department
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body ShiftSwapInput true "Swap request"
+// @Success 201 {object} models.ShiftSwapRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shift-swaps [post]
+func CreateShiftSwapRequest(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var input ShiftSwapInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	var own, target models.ShiftAssignment
+	if err := config.GetDB().First(&own, input.RequesterAssignmentID).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Shift assignment not found"})
+		return
+	}
+	if err := config.GetDB().First(&target, input.TargetAssignmentID).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Target shift assignment not found"})
+		return
+	}
+	if own.EmployeeID != actor.ID {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "You can only swap your own shifts"})
+		return
+	}
+	if target.EmployeeID == actor.ID || target.DepartmentID != own.DepartmentID {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Shifts can only be swapped with a colleague in the same department"})
+		return
+	}
+
+	var pending int64
+	if err := config.GetDB().Model(&models.ShiftSwapRequest{}).
+		Where("status = ? AND (requester_assignment_id IN ? OR target_assignment_id IN ?)",
+			models.RequestPending, []uint{own.ID, target.ID}, []uint{own.ID, target.ID}).
+		Count(&pending).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check pending swaps"})
+		return
+	}
+	if pending > 0 {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "One of the shifts already has a pending swap request"})
+		return
+	}
+
+	request := models.ShiftSwapRequest{
+		RequesterID:           actor.ID,
+		RequesterAssignmentID: own.ID,
+		TargetEmployeeID:      target.EmployeeID,
+		TargetAssignmentID:    target.ID,
+		Reason:                input.Reason,
+		Status:                models.RequestPending,
+	}
+	if err := config.GetDB().Create(&request).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create shift swap request"})
+		return
+	}
+	c.JSON(http.StatusCreated, request)
+}
+
+// findPendingSwap lấy yêu cầu đổi ca đang chờ xử lý theo ID trên đường dẫn
+func findPendingSwap(c *gin.Context) (models.ShiftSwapRequest, bool) {
+	var request models.ShiftSwapRequest
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&request).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Shift swap request not found"})
+		return request, false
+	}
+	if request.Status != models.RequestPending {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Shift swap request is not pending"})
+		return request, false
+	}
+	return request, true
+}
+
+// AcceptShiftSwapRequest godoc
+// @Summary Accept a shift swap
+// @Description The colleague asked to swap agrees; the swap then waits for manager approval
+// @Tags Shift
+// @Produce json
+// @Param id path int true "Swap request ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Success 200 {object} models.ShiftSwapRequest
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shift-swaps/{id}/accept [post]
+func AcceptShiftSwapRequest(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	request, ok := findPendingSwap(c)
+	if !ok {
+		return
+	}
+	if request.TargetEmployeeID != actor.ID {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only the requested colleague can accept this swap"})
+		return
+	}
+
+	request.TargetAccepted = true
+	if err := config.GetDB().Save(&request).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to accept shift swap request"})
+		return
+	}
+	c.JSON(http.StatusOK, request)
+}
+
+// swapConflicts kiểm tra mỗi nhân viên có thể nhận ca của người kia hay không
+func swapConflicts(own, target models.ShiftAssignment) ([]ScheduleConflict, error) {
+	conflicts := []ScheduleConflict{}
+	for _, pair := range []struct {
+		employeeID uint
+		date       time.Time
+	}{
+		{own.EmployeeID, target.Date.Time},
+		{target.EmployeeID, own.Date.Time},
+	} {
+		found, err := absenceConflicts(pair.employeeID, pair.date, pair.date)
+		if err != nil {
+			return nil, err
+		}
+		shifts, err := shiftConflicts(pair.employeeID, pair.date, pair.date, own.ID, target.ID)
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, append(found, shifts...)...)
+	}
+	return conflicts, nil
+}
+
+// ApproveShiftSwapRequest godoc
+// @Summary Approve a shift swap
+// @Description A manager approves an accepted swap; the two employees exchange their shifts
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Param id path int true "Swap request ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Optional comment"
+// @Success 200 {object} models.ShiftSwapRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ConflictResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shift-swaps/{id}/approve [post]
+func ApproveShiftSwapRequest(c *gin.Context) {
+	reviewShiftSwapRequest(c, models.RequestApproved)
+}
+
+// RejectShiftSwapRequest godoc
+// @Summary Reject a shift swap
+// @Description A manager rejects a swap, or the requested colleague declines it
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Param id path int true "Swap request ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body SalaryApprovalRequest false "Optional comment"
+// @Success 200 {object} models.ShiftSwapRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shift-swaps/{id}/reject [post]
+func RejectShiftSwapRequest(c *gin.Context) {
+	reviewShiftSwapRequest(c, models.RequestRejected)
+}
+
+// reviewShiftSwapRequest duyệt hoặc từ chối yêu cầu đổi ca
+func reviewShiftSwapRequest(c *gin.Context, status string) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var body SalaryApprovalRequest
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	request, ok := findPendingSwap(c)
+	if !ok {
+		return
+	}
+
+	// Người được đề nghị đổi ca có thể tự từ chối, còn lại chỉ quản lý được xử lý
+	declining := status == models.RequestRejected && request.TargetEmployeeID == actor.ID
+	if !declining {
+		if !hasRole(actor, managerRoles...) {
+			c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can review shift swaps"})
+			return
+		}
+		if actor.ID == request.RequesterID || actor.ID == request.TargetEmployeeID {
+			c.JSON(http.StatusForbidden, ErrorResponse{Error: "You cannot review your own shift swap"})
+			return
+		}
+	}
+	if status == models.RequestApproved && !request.TargetAccepted {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "The colleague has not accepted this swap yet"})
+		return
+	}
+
+	now := time.Now()
+	request.Status = status
+	request.ReviewedByID = &actor.ID
+	request.ReviewedAt = &now
+	request.ReviewComment = body.Comment
+
+	if status == models.RequestRejected {
+		if err := config.GetDB().Save(&request).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to reject shift swap request"})
+			return
+		}
+		c.JSON(http.StatusOK, request)
+		return
+	}
+
+	var own, target models.ShiftAssignment
+	if err := config.GetDB().First(&own, request.RequesterAssignmentID).Error; err != nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: errShiftChanged.Error()})
+		return
+	}
+	if err := config.GetDB().First(&target, request.TargetAssignmentID).Error; err != nil {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: errShiftChanged.Error()})
+		return
+	}
+	if own.EmployeeID != request.RequesterID || target.EmployeeID != request.TargetEmployeeID {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: errShiftChanged.Error()})
+		return
+	}
+
+	conflicts, err := swapConflicts(own, target)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to check schedule conflicts"})
+		return
+	}
+	if len(conflicts) > 0 {
+		c.JSON(http.StatusConflict, ConflictResponse{Error: "Swap conflicts with existing schedules", Conflicts: conflicts})
+		return
+	}
+
+	// Đổi ngày và ca giữa hai bản ghi để mỗi nhân viên vẫn chỉ có một ca mỗi ngày
+	err = config.GetDB().Transaction(func(tx *gorm.DB) error {
+		updates := []struct {
+			id         uint
+			employeeID uint
+			from       models.ShiftAssignment
+		}{
+			{own.ID, own.EmployeeID, target},
+			{target.ID, target.EmployeeID, own},
+		}
+		for _, u := range updates {
+			result := tx.Model(&models.ShiftAssignment{}).
+				Where("id = ? AND employee_id = ?", u.id, u.employeeID).
+				Updates(map[string]interface{}{"date": u.from.Date, "shift_template_id": u.from.ShiftTemplateID})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errShiftChanged
+			}
+		}
+		return tx.Save(&request).Error
+	})
+	if errors.Is(err, errShiftChanged) {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to approve shift swap request"})
+		return
+	}
+	c.JSON(http.StatusOK, request)
+}
@@ -0,0 +1,462 @@
+package controllers
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TransferRequest là yêu cầu điều chuyển nhân viên sang phòng ban khác
+type TransferRequest struct {
+	FromDepartmentID  *uint             `json:"from_department_id"` // Bỏ trống để thêm phòng ban kiêm nhiệm
+	ToDepartmentID    uint              `json:"to_department_id" binding:"required"`
+	EffectiveDate     models.CustomTime `json:"effective_date"`     // Mặc định là hôm nay
+	IsPrimary         *bool             `json:"is_primary"`         // Mặc định giữ cờ chính của phòng ban cũ
+	AllocationPercent *int              `json:"allocation_percent"` // Mặc định giữ tỉ lệ của phòng ban cũ
+}
+
+// PromotionRequest là yêu cầu thăng chức hoặc bổ nhiệm nhân viên vào chức vụ khác
+type PromotionRequest struct {
+	FromPositionID    *uint             `json:"from_position_id"` // Bỏ trống để thêm chức vụ kiêm nhiệm
+	ToPositionID      uint              `json:"to_position_id" binding:"required"`
+	EffectiveDate     models.CustomTime `json:"effective_date"`
+	IsPrimary         *bool             `json:"is_primary"`
+	AllocationPercent *int              `json:"allocation_percent"`
+	BaseSalary        int               `json:"base_salary"` // Mức lương mới, tạo yêu cầu điều chỉnh lương chờ duyệt
+	Note              string            `json:"note"`
+}
+
+// MembershipPeriod là một khoảng thời gian nhân viên thuộc một phòng ban hoặc giữ một chức vụ
+type MembershipPeriod struct {
+	ID                uint       `json:"id"` // ID phòng ban hoặc chức vụ
+	Name              string     `json:"name"`
+	StartDate         *time.Time `json:"start_date"`
+	EndDate           *time.Time `json:"end_date"` // Ngày cuối cùng, nil khi còn hiệu lực
+	IsPrimary         bool       `json:"is_primary"`
+	AllocationPercent int        `json:"allocation_percent"`
+}
+
+// EmployeeMemberships là lịch sử phòng ban và chức vụ của nhân viên
+type EmployeeMemberships struct {
+	EmployeeID  uint               `json:"employee_id"`
+	AsOf        string             `json:"as_of,omitempty"`
+	Departments []MembershipPeriod `json:"departments"`
+	Positions   []MembershipPeriod `json:"positions"`
+}
+
+// TransferResult là kết quả điều chuyển hoặc thăng chức
+type TransferResult struct {
+	Memberships          EmployeeMemberships          `json:"memberships"`
+	CompensationRevision *models.CompensationRevision `json:"compensation_revision,omitempty"`
+}
+
+// membershipError là lỗi nghiệp vụ khi thay đổi phòng ban, chức vụ kèm mã HTTP trả về client
+type membershipError struct {
+	status  int
+	message string
+}
+
+func (e *membershipError) Error() string {
+	return e.message
+}
+
+// membershipChange mô tả việc chuyển nhân viên từ fromID (nếu có) sang toID trong bảng nối table
+type membershipChange struct {
+	table, column string
+	employeeID    uint
+	fromID        *uint
+	toID          uint
+	effective     time.Time
+	isPrimary     *bool
+	allocation    *int
+}
+
+// apply đóng bản ghi cũ vào ngày trước ngày hiệu lực và mở bản ghi mới từ ngày hiệu lực.
+// Khi bản ghi mới là chính, bản ghi chính khác còn hiệu lực cũng được đóng và mở lại là bản ghi phụ
+// từ ngày hiệu lực, để lịch sử trước đó vẫn ghi đúng bản ghi chính.
+func (m membershipChange) apply(tx *gorm.DB) error {
+	current, err := loadMemberships(tx, m.table, m.column, m.employeeID, &m.effective)
+	if err != nil {
+		return err
+	}
+	day := m.effective.Format("2006-01-02")
+
+	var from *membershipRow
+	total, hasPrimary := 0, false
+	for i, row := range current {
+		if row.ReferenceID == m.toID {
+			return &membershipError{http.StatusConflict, "Employee already belongs to the target on the effective date"}
+		}
+		if m.fromID != nil && row.ReferenceID == *m.fromID {
+			from = &current[i]
+			continue
+		}
+		total += row.AllocationPercent
+		hasPrimary = hasPrimary || row.IsPrimary
+	}
+	if m.fromID != nil && from == nil {
+		return &membershipError{http.StatusBadRequest, "Employee does not belong to the source on the effective date"}
+	}
+	if from != nil && from.StartDate != nil && from.StartDate.Format("2006-01-02") >= day {
+		return &membershipError{http.StatusBadRequest, "effective_date must be after the current assignment started"}
+	}
+
+	isPrimary, allocation := !hasPrimary, 100-total
+	if from != nil {
+		isPrimary, allocation = from.IsPrimary, from.AllocationPercent
+	}
+	if m.isPrimary != nil {
+		isPrimary = *m.isPrimary
+	}
+	if m.allocation != nil {
+		allocation = *m.allocation
+	}
+	if allocation < 0 {
+		allocation = 0
+	}
+	if total+allocation > 100 {
+		return &membershipError{http.StatusBadRequest, fmt.Sprintf("Total allocation would be %d%%, must not exceed 100%%", total+allocation)}
+	}
+
+	if from != nil {
+		if err := tx.Table(m.table).
+			Where("employee_id = ? AND "+m.column+" = ? AND (end_date IS NULL OR end_date >= ?)", m.employeeID, from.ReferenceID, day).
+			Update("end_date", m.effective.AddDate(0, 0, -1).Format("2006-01-02")).Error; err != nil {
+			return err
+		}
+	}
+	if isPrimary {
+		for _, row := range current {
+			if !row.IsPrimary || (from != nil && row.ReferenceID == from.ReferenceID) {
+				continue
+			}
+			if err := m.demote(tx, row); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Table(m.table).Create(map[string]interface{}{
+		"employee_id":        m.employeeID,
+		m.column:             m.toID,
+		"start_date":         day,
+		"is_primary":         isPrimary,
+		"allocation_percent": allocation,
+	}).Error
+}
+
+// demote bỏ cờ chính của bản ghi row từ ngày hiệu lực. Bản ghi bắt đầu đúng ngày hiệu lực được sửa trực tiếp,
+// bản ghi bắt đầu trước đó được đóng vào ngày trước và mở lại là bản ghi phụ với cùng tỉ lệ và ngày kết thúc.
+func (m membershipChange) demote(tx *gorm.DB, row membershipRow) error {
+	day := m.effective.Format("2006-01-02")
+	query := activeOn(tx.Table(m.table), m.table, m.effective).
+		Where("employee_id = ? AND "+m.column+" = ? AND is_primary = ?", m.employeeID, row.ReferenceID, true)
+	if row.StartDate != nil && row.StartDate.Format("2006-01-02") == day {
+		return query.Update("is_primary", false).Error
+	}
+	if err := query.Update("end_date", m.effective.AddDate(0, 0, -1).Format("2006-01-02")).Error; err != nil {
+		return err
+	}
+	reopened := map[string]interface{}{
+		"employee_id":        m.employeeID,
+		m.column:             row.ReferenceID,
+		"start_date":         day,
+		"is_primary":         false,
+		"allocation_percent": row.AllocationPercent,
+	}
+	if row.EndDate != nil {
+		reopened["end_date"] = row.EndDate.Format("2006-01-02")
+	}
+	return tx.Table(m.table).Create(reopened).Error
+}
+
+// employeeMemberships tải lịch sử phòng ban và chức vụ của nhân viên, chỉ lấy bản ghi hiệu lực trong ngày asOf nếu có
+func employeeMemberships(db *gorm.DB, employeeID uint, asOf *time.Time) (EmployeeMemberships, error) {
+	result := EmployeeMemberships{EmployeeID: employeeID}
+	if asOf != nil {
+		result.AsOf = asOf.Format("2006-01-02")
+	}
+
+	departments, err := loadMemberships(db, "employee_departments", "department_id", employeeID, asOf)
+	if err != nil {
+		return result, err
+	}
+	var departmentNames []struct {
+		ID   uint
+		Name string
+	}
+	if err := db.Unscoped().Model(&models.Department{}).Select("id, name").Find(&departmentNames).Error; err != nil {
+		return result, err
+	}
+	names := map[uint]string{}
+	for _, d := range departmentNames {
+		names[d.ID] = d.Name
+	}
+	result.Departments = membershipPeriods(departments, names)
+
+	positions, err := loadMemberships(db, "employee_positions", "position_id", employeeID, asOf)
+	if err != nil {
+		return result, err
+	}
+	var positionTitles []struct {
+		ID    uint
+		Title string
+	}
+	if err := db.Unscoped().Model(&models.Position{}).Select("id, title").Find(&positionTitles).Error; err != nil {
+		return result, err
+	}
+	titles := map[uint]string{}
+	for _, p := range positionTitles {
+		titles[p.ID] = p.Title
+	}
+	result.Positions = membershipPeriods(positions, titles)
+	return result, nil
+}
+
+// membershipPeriods chuyển các bản ghi bảng nối thành khoảng thời gian kèm tên
+func membershipPeriods(rows []membershipRow, names map[uint]string) []MembershipPeriod {
+	periods := make([]MembershipPeriod, 0, len(rows))
+	for _, row := range rows {
+		periods = append(periods, MembershipPeriod{
+			ID:                row.ReferenceID,
+			Name:              names[row.ReferenceID],
+			StartDate:         row.StartDate,
+			EndDate:           row.EndDate,
+			IsPrimary:         row.IsPrimary,
+			AllocationPercent: row.AllocationPercent,
+		})
+	}
+	return periods
+}
+
+// effectiveDay trả về ngày hiệu lực của yêu cầu, mặc định là hôm nay
+func effectiveDay(date models.CustomTime) time.Time {
+	if date.IsZero() {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	}
+	return date.Time
+}
+
+// transferTarget đọc nhân viên theo tham số id và kiểm tra người thực hiện là HR manager.
+// Nếu không hợp lệ, hàm tự trả lỗi về client và trả về false.
+func transferTarget(c *gin.Context) (models.Employee, models.Employee, bool) {
+	var employee models.Employee
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+		return employee, employee, false
+	}
+	actor, ok := currentActor(c)
+	if !ok {
+		return employee, actor, false
+	}
+	if !hasRole(actor, hrManagerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only HR managers can transfer or promote employees"})
+		return employee, actor, false
+	}
+	if err := config.GetDB().First(&employee, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return employee, actor, false
+	}
+	return employee, actor, true
+}
+
+// writeMembershipError trả lỗi thay đổi phòng ban, chức vụ về client
+func writeMembershipError(c *gin.Context, err error, fallback string) {
+	var membershipErr *membershipError
+	if errors.As(err, &membershipErr) {
+		c.JSON(membershipErr.status, ErrorResponse{Error: membershipErr.message})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fallback})
+}
+
+// TransferEmployee godoc
+// @Summary Transfer an employee to another department
+// @Description An HR manager moves an employee from one department to another from the effective date. The old membership ends the day before; without from_department_id the new department is added alongside the current ones. If the new department becomes primary, the previous primary membership is split at the effective date so earlier history is unchanged.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param transfer body TransferRequest true "Transfer details"
+// @Success 200 {object} TransferResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/transfer [post]
+func TransferEmployee(c *gin.Context) {
+	employee, _, ok := transferTarget(c)
+	if !ok {
+		return
+	}
+
+	var request TransferRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	if request.AllocationPercent != nil && (*request.AllocationPercent < 0 || *request.AllocationPercent > 100) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "allocation_percent must be between 0 and 100"})
+		return
+	}
+	var department models.Department
+	if err := config.GetDB().First(&department, request.ToDepartmentID).Error; err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Department with ID %d does not exist", request.ToDepartmentID)})
+		return
+	}
+
+	change := membershipChange{
+		table: "employee_departments", column: "department_id",
+		employeeID: employee.ID, fromID: request.FromDepartmentID, toID: department.ID,
+		effective: effectiveDay(request.EffectiveDate), isPrimary: request.IsPrimary, allocation: request.AllocationPercent,
+	}
+	var result TransferResult
+	err := config.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := change.apply(tx); err != nil {
+			return err
+		}
+		var err error
+		result.Memberships, err = employeeMemberships(tx, employee.ID, nil)
+		return err
+	})
+	if err != nil {
+		writeMembershipError(c, err, "Failed to transfer employee")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// PromoteEmployee godoc
+// @Summary Promote an employee to another position
+// @Description An HR manager moves an employee from one position to another from the effective date. The old position ends the day before. When base_salary is given, a pending promotion compensation revision is created with the same effective date; a Warning header is returned if it is outside the pay grade band.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param promotion body PromotionRequest true "Promotion details"
+// @Success 200 {object} TransferResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/promote [post]
+func PromoteEmployee(c *gin.Context) {
+	employee, actor, ok := transferTarget(c)
+	if !ok {
+		return
+	}
+
+	var request PromotionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+	if request.AllocationPercent != nil && (*request.AllocationPercent < 0 || *request.AllocationPercent > 100) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "allocation_percent must be between 0 and 100"})
+		return
+	}
+	if request.BaseSalary < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "base_salary must be positive"})
+		return
+	}
+	var position models.Position
+	if err := config.GetDB().First(&position, request.ToPositionID).Error; err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Position with ID %d does not exist", request.ToPositionID)})
+		return
+	}
+
+	effective := effectiveDay(request.EffectiveDate)
+	change := membershipChange{
+		table: "employee_positions", column: "position_id",
+		employeeID: employee.ID, fromID: request.FromPositionID, toID: position.ID,
+		effective: effective, isPrimary: request.IsPrimary, allocation: request.AllocationPercent,
+	}
+	var result TransferResult
+	err := config.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := change.apply(tx); err != nil {
+			return err
+		}
+		if request.BaseSalary > 0 {
+			revision := models.CompensationRevision{
+				EmployeeID:    employee.ID,
+				BaseSalary:    request.BaseSalary,
+				EffectiveDate: models.CustomTime{Time: effective},
+				Reason:        models.CompensationPromotion,
+				Note:          request.Note,
+				Status:        models.RequestPending,
+				RequestedByID: actor.ID,
+			}
+			if err := tx.Create(&revision).Error; err != nil {
+				return err
+			}
+			result.CompensationRevision = &revision
+		}
+		var err error
+		result.Memberships, err = employeeMemberships(tx, employee.ID, nil)
+		return err
+	})
+	if err != nil {
+		writeMembershipError(c, err, "Failed to promote employee")
+		return
+	}
+
+	if result.CompensationRevision != nil {
+		// Cảnh báo khi mức lương mới nằm ngoài khung lương của chức vụ
+		writeBandWarnings(c, employee.ID, request.BaseSalary)
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetEmployeeMemberships godoc
+// @Summary Get an employee's department and position history
+// @Description Get every department and position membership of an employee with start and end dates, primary flag and allocation. With as_of only the memberships active on that date are returned.
+// @Tags Employee
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param as_of query string false "Only memberships active on this date (YYYY-MM-DD)"
+// @Success 200 {object} EmployeeMemberships
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/employees/{id}/memberships [get]
+func GetEmployeeMemberships(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid employee ID"})
+		return
+	}
+	var asOf *time.Time
+	if c.Query("as_of") != "" {
+		day, ok := parseAsOf(c)
+		if !ok {
+			return
+		}
+		asOf = &day
+	}
+
+	var employee models.Employee
+	if err := config.GetDB().First(&employee, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Employee not found"})
+		return
+	}
+	result, err := employeeMemberships(config.GetDB(), employee.ID, asOf)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch memberships"})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
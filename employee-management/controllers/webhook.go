@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// webhookTimeout giới hạn thời gian gửi thông báo sang hệ thống bên ngoài
+const webhookTimeout = 10 * time.Second
+
+// postWebhook gửi payload JSON tới target trong nền, lỗi chỉ được ghi log.
+// subject mô tả đối tượng được gửi trong log, ví dụ "vacancy 12". Log chỉ ghi host vì
+// đường dẫn và query của webhook thường chứa token bí mật.
+func postWebhook(target string, payload []byte, subject string) {
+	host := "webhook"
+	if parsed, err := url.Parse(target); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+	go func() {
+		client := &http.Client{Timeout: webhookTimeout}
+		resp, err := client.Post(target, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			// url.Error chứa cả địa chỉ đầy đủ nên chỉ ghi lỗi bên trong
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+			log.Printf("Failed to send %s to %s: %v", subject, host, err)
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			log.Printf("Webhook %s rejected %s: %s", host, subject, resp.Status)
+		}
+	}()
+}
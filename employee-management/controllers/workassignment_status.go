@@ -0,0 +1,358 @@
+package controllers
+
+import (
+	"employee-management/calendar"
+	"employee-management/config"
+	"employee-management/models"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// assignmentTransition mô tả một bước chuyển trạng thái công tác hợp lệ
+type assignmentTransition struct {
+	from []string
+	to   string
+}
+
+// assignmentTransitions liệt kê các thao tác chuyển trạng thái được phép.
+// Trạng thái overdue chỉ do hệ thống gán khi công tác quá hạn, và được gỡ khi ngày kết thúc được dời sang tương lai.
+var assignmentTransitions = map[string]assignmentTransition{
+	"start":    {from: []string{models.AssignmentPlanned}, to: models.AssignmentInProgress},
+	"complete": {from: []string{models.AssignmentInProgress, models.AssignmentOverdue}, to: models.AssignmentCompleted},
+	"cancel":   {from: []string{models.AssignmentPlanned, models.AssignmentInProgress, models.AssignmentOverdue}, to: models.AssignmentCancelled},
+}
+
+// errAssignmentChanged được trả về khi trạng thái công tác thay đổi giữa lúc đọc và ghi
+var errAssignmentChanged = errors.New("work assignment status changed concurrently")
+
+// WorkAssignmentTransitionRequest là dữ liệu gửi kèm khi chuyển trạng thái công tác
+type WorkAssignmentTransitionRequest struct {
+	Note string `json:"note"`
+}
+
+// WorkAssignmentProgressRequest là dữ liệu cập nhật tiến độ công tác
+type WorkAssignmentProgressRequest struct {
+	Progress *int   `json:"progress" binding:"required"`
+	Notes    string `json:"notes"`
+}
+
+// recordAssignmentEvent lưu lịch sử thay đổi của công tác, actor nil nghĩa là hệ thống
+func recordAssignmentEvent(tx *gorm.DB, assignment models.WorkAssignment, action, from string, actor *models.Employee, note string) error {
+	event := models.WorkAssignmentEvent{
+		WorkAssignmentID: assignment.ID,
+		Action:           action,
+		FromStatus:       from,
+		ToStatus:         assignment.Status,
+		Progress:         assignment.Progress,
+		Note:             note,
+	}
+	if actor != nil {
+		event.ActorID = &actor.ID
+	}
+	return tx.Create(&event).Error
+}
+
+// canManageAssignment kiểm tra người thực hiện là người được giao hoặc quản lý
+func canManageAssignment(actor models.Employee, assignment models.WorkAssignment) bool {
+	return actor.ID == assignment.EmployeeID || hasRole(actor, managerRoles...)
+}
+
+// MarkOverdueWorkAssignments chuyển các công tác đã quá ngày kết thúc mà chưa hoàn thành sang overdue
+func MarkOverdueWorkAssignments() error {
+	var assignments []models.WorkAssignment
+	// end_date lưu dạng timestamp, công tác không có ngày kết thúc thì không bao giờ quá hạn
+	if err := config.GetDB().
+		Where("status IN ? AND end_date < ? AND end_date >= ?",
+			[]string{models.AssignmentPlanned, models.AssignmentInProgress}, calendar.DateKey(time.Now()), "1900-01-01").
+		Find(&assignments).Error; err != nil {
+		return err
+	}
+
+	for _, assignment := range assignments {
+		from := assignment.Status
+		err := config.GetDB().Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&models.WorkAssignment{}).
+				Where("id = ? AND status = ?", assignment.ID, from).
+				Update("status", models.AssignmentOverdue)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+			assignment.Status = models.AssignmentOverdue
+			return recordAssignmentEvent(tx, assignment, "overdue", from, nil, "")
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resumeOverdueAssignment đưa công tác quá hạn về trạng thái trước khi quá hạn nếu ngày kết thúc
+// đã được dời tới hôm nay trở đi hoặc bỏ trống, rồi ghi lịch sử
+func resumeOverdueAssignment(tx *gorm.DB, assignment *models.WorkAssignment) error {
+	today := calendar.DateKey(time.Now())
+	if assignment.Status != models.AssignmentOverdue ||
+		(!assignment.EndDate.IsZero() && calendar.DateKey(assignment.EndDate.Time) < today) {
+		return nil
+	}
+
+	// Lấy lại trạng thái trước lần bị đánh dấu quá hạn gần nhất, dữ liệu cũ không có lịch sử thì suy ra từ ngày bắt đầu
+	status := models.AssignmentInProgress
+	if calendar.DateKey(assignment.StartDate.Time) > today {
+		status = models.AssignmentPlanned
+	}
+	var event models.WorkAssignmentEvent
+	err := tx.Where("work_assignment_id = ? AND action = ?", assignment.ID, "overdue").Order("id DESC").First(&event).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if event.FromStatus == models.AssignmentPlanned || event.FromStatus == models.AssignmentInProgress {
+		status = event.FromStatus
+	}
+
+	assignment.Status = status
+	note := "End date removed"
+	if !assignment.EndDate.IsZero() {
+		note = "End date moved to " + calendar.DateKey(assignment.EndDate.Time)
+	}
+	if err := recordAssignmentEvent(tx, *assignment, "resume", models.AssignmentOverdue, nil, note); err != nil {
+		return err
+	}
+	return nil
+}
+
+// changeAssignmentStatus thực hiện một thao tác chuyển trạng thái công tác (start/complete/cancel)
+func changeAssignmentStatus(c *gin.Context, action string) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var body WorkAssignmentTransitionRequest
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid input data"})
+		return
+	}
+
+	var assignment models.WorkAssignment
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&assignment).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Work assignment not found"})
+		return
+	}
+	if !canManageAssignment(actor, assignment) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only the assignee or a manager can change this work assignment"})
+		return
+	}
+	// Hủy công tác là quyết định của quản lý
+	if action == "cancel" && !hasRole(actor, managerRoles...) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only managers can cancel work assignments"})
+		return
+	}
+
+	transition := assignmentTransitions[action]
+	allowed := false
+	for _, from := range transition.from {
+		if assignment.Status == from {
+			allowed = true
+		}
+	}
+	if !allowed {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Cannot " + action + " a work assignment in status " + assignment.Status})
+		return
+	}
+
+	from := assignment.Status
+	updates := map[string]interface{}{"status": transition.to}
+	if transition.to == models.AssignmentCompleted {
+		now := time.Now()
+		updates["completed_at"] = now
+		updates["progress"] = 100
+		assignment.CompletedAt = &now
+		assignment.Progress = 100
+	}
+	assignment.Status = transition.to
+
+	err := config.GetDB().Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.WorkAssignment{}).Where("id = ? AND status = ?", assignment.ID, from).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errAssignmentChanged
+		}
+		return recordAssignmentEvent(tx, assignment, action, from, &actor, body.Note)
+	})
+	if errors.Is(err, errAssignmentChanged) {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update work assignment status"})
+		return
+	}
+	c.JSON(http.StatusOK, assignment)
+}
+
+// StartWorkAssignment godoc
+// @Summary Start a work assignment
+// @Description Move a planned work assignment to in progress
+// @Tags WorkAssignment
+// @Accept json
+// @Produce json
+// @Param id path int true "Work assignment ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body WorkAssignmentTransitionRequest false "Optional note"
+// @Success 200 {object} models.WorkAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workassignments/{id}/start [post]
+func StartWorkAssignment(c *gin.Context) {
+	changeAssignmentStatus(c, "start")
+}
+
+// CompleteWorkAssignment godoc
+// @Summary Complete a work assignment
+// @Description Mark an in-progress or overdue work assignment as completed; progress is set to 100% and the completion time recorded
+// @Tags WorkAssignment
+// @Accept json
+// @Produce json
+// @Param id path int true "Work assignment ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body WorkAssignmentTransitionRequest false "Optional note"
+// @Success 200 {object} models.WorkAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workassignments/{id}/complete [post]
+func CompleteWorkAssignment(c *gin.Context) {
+	changeAssignmentStatus(c, "complete")
+}
+
+// CancelWorkAssignment godoc
+// @Summary Cancel a work assignment
+// @Description A manager cancels a work assignment that is not completed
+// @Tags WorkAssignment
+// @Accept json
+// @Produce json
+// @Param id path int true "Work assignment ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body WorkAssignmentTransitionRequest false "Optional note"
+// @Success 200 {object} models.WorkAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workassignments/{id}/cancel [post]
+func CancelWorkAssignment(c *gin.Context) {
+	changeAssignmentStatus(c, "cancel")
+}
+
+// UpdateWorkAssignmentProgress godoc
+// @Summary Update work assignment progress
+// @Description Record the completion percentage and notes of an in-progress or overdue work assignment
+// @Tags WorkAssignment
+// @Accept json
+// @Produce json
+// @Param id path int true "Work assignment ID"
+// @Param X-Employee-ID header int true "Actor employee ID"
+// @Param request body WorkAssignmentProgressRequest true "Progress (0-100) and notes"
+// @Success 200 {object} models.WorkAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workassignments/{id}/progress [put]
+func UpdateWorkAssignmentProgress(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var body WorkAssignmentProgressRequest
+	if err := c.ShouldBindJSON(&body); err != nil || *body.Progress < 0 || *body.Progress > 100 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "progress must be between 0 and 100"})
+		return
+	}
+
+	var assignment models.WorkAssignment
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&assignment).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Work assignment not found"})
+		return
+	}
+	if !canManageAssignment(actor, assignment) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Only the assignee or a manager can change this work assignment"})
+		return
+	}
+	if assignment.Status != models.AssignmentInProgress && assignment.Status != models.AssignmentOverdue {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Progress can only be updated while the work assignment is in progress or overdue"})
+		return
+	}
+
+	assignment.Progress = *body.Progress
+	if body.Notes != "" {
+		assignment.Notes = body.Notes
+	}
+
+	err := config.GetDB().Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.WorkAssignment{}).
+			Where("id = ? AND status = ?", assignment.ID, assignment.Status).
+			Updates(map[string]interface{}{"progress": assignment.Progress, "notes": assignment.Notes})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errAssignmentChanged
+		}
+		return recordAssignmentEvent(tx, assignment, "progress", assignment.Status, &actor, body.Notes)
+	})
+	if errors.Is(err, errAssignmentChanged) {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to update work assignment progress"})
+		return
+	}
+	c.JSON(http.StatusOK, assignment)
+}
+
+// GetWorkAssignmentHistory godoc
+// @Summary Get work assignment history
+// @Description List status changes and progress updates of a work assignment in chronological order
+// @Tags WorkAssignment
+// @Produce json
+// @Param id path int true "Work assignment ID"
+// @Success 200 {array} models.WorkAssignmentEvent
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workassignments/{id}/history [get]
+func GetWorkAssignmentHistory(c *gin.Context) {
+	var assignment models.WorkAssignment
+	if err := config.GetDB().Where("id = ?", c.Param("id")).First(&assignment).Error; err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Work assignment not found"})
+		return
+	}
+
+	var events []models.WorkAssignmentEvent
+	if err := config.GetDB().Where("work_assignment_id = ?", assignment.ID).Order("created_at, id").Find(&events).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch work assignment history"})
+		return
+	}
+	c.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,176 @@
+// Package ical tạo và đọc lịch iCalendar (RFC 5545) cho các sự kiện cả ngày
+// như công tác, nghỉ phép và ngày lễ.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Các định dạng ngày giờ dùng trong iCalendar
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+	// maxLineOctets là độ dài tối đa của một dòng trước khi phải gấp dòng
+	maxLineOctets = 75
+)
+
+// Calendar là một lịch gồm nhiều sự kiện
+type Calendar struct {
+	Name   string // Tên lịch hiển thị trên ứng dụng (X-WR-CALNAME)
+	ProdID string
+	Events []Event
+}
+
+// Event là một sự kiện cả ngày, End là ngày cuối cùng của sự kiện (tính cả ngày đó)
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Category    string
+	Status      string // CONFIRMED, TENTATIVE hoặc CANCELLED
+	Start       time.Time
+	End         time.Time
+	Attendees   []string // Email người tham gia, chỉ dùng khi đọc lịch
+}
+
+// Write ghi lịch theo định dạng iCalendar, stamp là thời điểm tạo lịch (DTSTAMP)
+func Write(w io.Writer, cal Calendar, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	prodID := cal.ProdID
+	if prodID == "" {
+		prodID = "-//employee-management//EN"
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + prodID,
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	if cal.Name != "" {
+		lines = append(lines, "X-WR-CALNAME:"+escapeText(cal.Name))
+	}
+	dtstamp := stamp.UTC().Format(dateTimeLayout) + "Z"
+	for _, event := range cal.Events {
+		end := event.End
+		if end.IsZero() || end.Before(event.Start) {
+			end = event.Start
+		}
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+escapeText(event.UID),
+			"DTSTAMP:"+dtstamp,
+			"DTSTART;VALUE=DATE:"+event.Start.Format(dateLayout),
+			// DTEND của sự kiện cả ngày là ngày kế tiếp ngày kết thúc
+			"DTEND;VALUE=DATE:"+end.AddDate(0, 0, 1).Format(dateLayout),
+			"SUMMARY:"+escapeText(event.Summary),
+			"TRANSP:OPAQUE",
+		)
+		if event.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		if event.Location != "" {
+			lines = append(lines, "LOCATION:"+escapeText(event.Location))
+		}
+		if event.Category != "" {
+			lines = append(lines, "CATEGORIES:"+escapeText(event.Category))
+		}
+		if event.Status != "" {
+			lines = append(lines, "STATUS:"+event.Status)
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := bw.WriteString(foldLine(line)); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// escapeText thoát các ký tự đặc biệt trong giá trị TEXT
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// unescapeText khôi phục giá trị TEXT đã thoát
+func unescapeText(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+// foldLine gấp dòng dài hơn 75 octet, không cắt giữa ký tự UTF-8, và thêm CRLF
+func foldLine(line string) string {
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Dòng tiếp theo bắt đầu bằng một khoảng trắng
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// isRuneStart kiểm tra byte có phải là byte đầu của một ký tự UTF-8
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// parseDate đọc giá trị DATE hoặc DATE-TIME và quy về giờ địa phương.
+// Giá trị kết thúc bằng "Z" là giờ UTC, giá trị có tham số TZID được đọc theo múi giờ đó;
+// TZID không có trong cơ sở dữ liệu múi giờ (ví dụ tên múi giờ Windows) được coi là giờ địa phương.
+// allDay cho biết giá trị là DATE (không có giờ).
+func parseDate(value, tzid string) (date time.Time, allDay bool, err error) {
+	if len(value) == len(dateLayout) {
+		date, err = time.ParseInLocation(dateLayout, value, time.Local)
+		return date, true, err
+	}
+	location := time.Local
+	if utc, ok := strings.CutSuffix(value, "Z"); ok {
+		value, location = utc, time.UTC
+	} else if tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			location = zone
+		}
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, value, location)
+	if err != nil {
+		return date, false, fmt.Errorf("invalid date %q", value)
+	}
+	return t.In(time.Local), false, nil
+}
@@ -0,0 +1,144 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// property là một dòng nội dung đã tách thành tên, tham số và giá trị
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse đọc các sự kiện VEVENT trong lịch iCalendar.
+// Sự kiện có giờ được quy về ngày, DTEND là mốc kết thúc không tính nên được lùi lại thành ngày cuối của sự kiện.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	var end *property
+	for number, line := range lines {
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number+1, err)
+		}
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT"):
+			current, end = &Event{}, nil
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT"):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", number+1)
+			}
+			if current.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: event %q has no DTSTART", number+1, current.Summary)
+			}
+			current.End = current.Start
+			if end != nil {
+				last, allDay, err := parseDate(end.value, end.params["TZID"])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: DTEND: %w", number+1, err)
+				}
+				// DTEND không thuộc sự kiện: ngày kế tiếp với sự kiện cả ngày, hoặc 00:00 của ngày kế tiếp
+				if allDay || (last.Hour() == 0 && last.Minute() == 0 && last.Second() == 0) {
+					last = last.AddDate(0, 0, -1)
+				}
+				last = time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.Local)
+				if !last.Before(current.Start) {
+					current.End = last
+				}
+			}
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case prop.name == "UID":
+			current.UID = prop.value
+		case prop.name == "SUMMARY":
+			current.Summary = unescapeText(prop.value)
+		case prop.name == "DESCRIPTION":
+			current.Description = unescapeText(prop.value)
+		case prop.name == "LOCATION":
+			current.Location = unescapeText(prop.value)
+		case prop.name == "CATEGORIES":
+			current.Category = unescapeText(prop.value)
+		case prop.name == "STATUS":
+			current.Status = strings.ToUpper(prop.value)
+		case prop.name == "ATTENDEE":
+			if email := strings.TrimSpace(prop.value); len(email) > 7 && strings.EqualFold(email[:7], "mailto:") {
+				current.Attendees = append(current.Attendees, strings.ToLower(email[7:]))
+			}
+		case prop.name == "DTSTART":
+			start, _, err := parseDate(prop.value, prop.params["TZID"])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DTSTART: %w", number+1, err)
+			}
+			current.Start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+		case prop.name == "DTEND":
+			end = &prop
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("unterminated VEVENT")
+	}
+	return events, nil
+}
+
+// unfold đọc các dòng nội dung và nối các dòng đã bị gấp (bắt đầu bằng khoảng trắng hoặc tab)
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseProperty tách dòng "NAME;PARAM=VALUE:value" thành các phần
+func parseProperty(line string) (property, error) {
+	prop := property{params: map[string]string{}}
+
+	// Dấu ":" đầu tiên nằm ngoài cặp nháy kép phân cách tên/tham số với giá trị
+	quoted := false
+	colon := -1
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return prop, fmt.Errorf("invalid content line %q", line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	prop.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	prop.value = line[colon+1:]
+	return prop, nil
+}
@@ -0,0 +1,40 @@
+// Package jobs chạy các tác vụ định kỳ trong nền khi server hoạt động.
+package jobs
+
+import (
+	"employee-management/controllers"
+	"log"
+	"time"
+)
+
+// job là một tác vụ chạy lặp lại sau mỗi khoảng thời gian
+type job struct {
+	name     string
+	interval time.Duration
+	run      func() error
+}
+
+// all liệt kê các tác vụ định kỳ
+var all = []job{
+	{name: "mark overdue work assignments", interval: time.Hour, run: controllers.MarkOverdueWorkAssignments},
+	{name: "flag expiring contracts", interval: 24 * time.Hour, run: controllers.FlagExpiringContracts},
+}
+
+// Start chạy mỗi tác vụ ngay lập tức rồi lặp lại theo chu kỳ trong goroutine riêng
+func Start() {
+	for _, j := range all {
+		go loop(j)
+	}
+}
+
+// loop chạy một tác vụ theo chu kỳ, lỗi chỉ được ghi log để lần chạy sau thử lại
+func loop(j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		if err := j.run(); err != nil {
+			log.Printf("Job %q failed: %v", j.name, err)
+		}
+		<-ticker.C
+	}
+}
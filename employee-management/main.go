@@ -2,7 +2,9 @@ package main
 
 import (
 	"employee-management/config"
+	"employee-management/jobs"
 	"employee-management/middleware"
+	"employee-management/migrations"
 	"employee-management/models"
 	"employee-management/routes"
 	"fmt"
@@ -34,10 +36,36 @@ func main() {
 	// Tự động migrate bảng Employee và các bảng khác
 	err := config.GetDB().AutoMigrate(
 		&models.Department{},
+		&models.JobFamily{},
+		&models.PayGrade{},
 		&models.Position{},
 		&models.Employee{},
 		&models.Salary{},
+		&models.SalaryApproval{},
+		&models.CompensationRevision{},
+		&models.AttendanceRecord{},
+		&models.AttendanceCorrection{},
+		&models.LeaveType{},
+		&models.LeaveRequest{},
+		&models.Holiday{},
+		&models.ShiftTemplate{},
+		&models.ShiftAssignment{},
+		&models.ShiftSwapRequest{},
+		&models.OvertimeRequest{},
+		&models.OvertimeEntry{},
+		&models.SalaryEarningLine{},
+		&models.SalaryAdvance{},
+		&models.AdvanceRepayment{},
+		&models.PerDiemRule{},
+		&models.ExpenseClaim{},
 		&models.WorkAssignment{},
+		&models.WorkAssignmentEvent{},
+		&models.CalendarFeed{},
+		&models.HeadcountPlan{},
+		&models.Vacancy{},
+		&models.EmploymentContract{},
+		&models.ContractExpiryAlert{},
+		&models.EmployeeDocument{},
 		&models.EmployeeDepartment{},
 		&models.EmployeePosition{},
 	)
@@ -45,8 +73,17 @@ func main() {
 		fmt.Println("Error during migration:", err)
 		return
 	}
+
+	// Chuyển đổi dữ liệu cũ sang cấu trúc mới
+	if err := migrations.Run(config.GetDB()); err != nil {
+		fmt.Println("Error during data migration:", err)
+		return
+	}
 	fmt.Println("Tables migrated successfully.")
 
+	// Chạy các tác vụ định kỳ (đánh dấu công tác quá hạn...)
+	jobs.Start()
+
 	// Khởi tạo router
 	router := routes.SetupRouter()
 
@@ -11,7 +11,7 @@ func CORS() gin.HandlerFunc {
 		// Thiết lập các header CORS
 		c.Writer.Header().Set("Access-Control-Allow-Origin", origin) // Chỉ định nguồn gốc
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Employee-ID")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true") // Cho phép sử dụng thông tin xác thực (cookie, headers, etc.)
 
 		// Nếu là yêu cầu OPTIONS (preflight request), phản hồi ngay lập tức
@@ -0,0 +1,139 @@
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"employee-management/models"
+	"employee-management/storage"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// legacyAttachment là một loại tệp đính kèm cũ lưu theo đường dẫn trên ổ đĩa
+type legacyAttachment struct {
+	table          string
+	pathColumn     string
+	nameColumn     string
+	documentColumn string
+	uploaderColumn string
+	category       string
+}
+
+// legacyAttachments là các cột đường dẫn cũ của chứng từ chi phí và bản hợp đồng đã ký
+var legacyAttachments = []legacyAttachment{
+	{"expense_claims", "receipt_path", "receipt_name", "receipt_document_id", "employee_id", models.DocumentReceipt},
+	{"employment_contracts", "document_path", "document_name", "document_id", "created_by_id", models.DocumentContract},
+}
+
+// moveAttachments chuyển chứng từ chi phí và bản hợp đồng đã ký từ thư mục riêng sang kho giấy tờ nhân viên.
+// Bản ghi đã chuyển có document id và đường dẫn rỗng nên không bị chuyển lại; tệp không còn trên ổ đĩa
+// chỉ được ghi log để HR tải lại.
+func moveAttachments(db *gorm.DB) error {
+	var store storage.Storage
+	for _, attachment := range legacyAttachments {
+		if !db.Migrator().HasColumn(attachment.table, attachment.pathColumn) {
+			continue
+		}
+		var rows []struct {
+			ID         uint
+			EmployeeID uint
+			UploaderID uint
+			Path       string
+			Name       string
+		}
+		if err := db.Table(attachment.table).
+			Select("id, employee_id, " + attachment.uploaderColumn + " AS uploader_id, " +
+				attachment.pathColumn + " AS path, " + attachment.nameColumn + " AS name").
+			Where(attachment.pathColumn + " <> '' AND " + attachment.documentColumn + " IS NULL").
+			Scan(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		if store == nil {
+			var err error
+			if store, err = storage.New(storage.EnvConfig()); err != nil {
+				return err
+			}
+		}
+
+		for _, row := range rows {
+			document := models.EmployeeDocument{
+				EmployeeID:   row.EmployeeID,
+				Category:     attachment.category,
+				FileName:     row.Name,
+				UploadedByID: row.UploaderID,
+			}
+			err := moveAttachmentFile(store, row.Path, &document)
+			if errors.Is(err, os.ErrNotExist) {
+				log.Printf("Attachment %s of %s %d is missing, skipped", row.Path, attachment.table, row.ID)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			err = db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Create(&document).Error; err != nil {
+					return err
+				}
+				return tx.Table(attachment.table).Where("id = ?", row.ID).
+					Updates(map[string]interface{}{attachment.documentColumn: document.ID, attachment.pathColumn: ""}).Error
+			})
+			if err != nil {
+				store.Delete(context.Background(), document.StorageKey)
+				return err
+			}
+			if err := os.Remove(row.Path); err != nil {
+				log.Printf("Failed to remove moved attachment %s: %v", row.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// moveAttachmentFile chép tệp ở path vào backend lưu trữ và điền định dạng, dung lượng, checksum của giấy tờ
+func moveAttachmentFile(store storage.Storage, path string, document *models.EmployeeDocument) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return err
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	key := models.DocumentStorageKey(document.EmployeeID, strings.ToLower(filepath.Ext(path)))
+	if err := store.Put(context.Background(), key, io.TeeReader(file, hash), info.Size(), contentType); err != nil {
+		return err
+	}
+	if document.FileName == "" {
+		document.FileName = filepath.Base(path)
+	}
+	document.ContentType = contentType
+	document.Size = info.Size()
+	document.Checksum = hex.EncodeToString(hash.Sum(nil))
+	document.StorageKey = key
+	return nil
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"employee-management/models"
+
+	"gorm.io/gorm"
+)
+
+// defaultLeaveTypes là các loại nghỉ phép cơ bản theo Bộ luật Lao động
+var defaultLeaveTypes = []models.LeaveType{
+	{
+		Code:                "annual",
+		Name:                "Nghỉ phép năm",
+		Paid:                true,
+		AnnualDays:          12,
+		MonthlyAccrual:      true,
+		SeniorityBonusDays:  1,
+		SeniorityEveryYears: 5,
+		MaxCarryOverDays:    5,
+	},
+	{Code: "sick", Name: "Nghỉ ốm", Paid: false},
+	{Code: "unpaid", Name: "Nghỉ không lương", Paid: false},
+}
+
+// seedLeaveTypes tạo các loại nghỉ phép mặc định nếu chưa có
+func seedLeaveTypes(db *gorm.DB) error {
+	for _, leaveType := range defaultLeaveTypes {
+		if err := db.Where(models.LeaveType{Code: leaveType.Code}).FirstOrCreate(&leaveType).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
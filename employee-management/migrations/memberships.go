@@ -0,0 +1,83 @@
+package migrations
+
+import (
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// normalizeMemberships bổ sung dữ liệu cho các bản ghi phòng ban, chức vụ cũ chưa có thời hạn.
+// Mỗi nhân viên có một bản ghi chính (ID phòng ban/chức vụ nhỏ nhất), và nếu tổng tỉ lệ
+// vượt 100% thì tỉ lệ được chia đều, phần dư cộng vào bản ghi chính.
+func normalizeMemberships(db *gorm.DB) error {
+	for _, junction := range []struct{ table, column string }{
+		{"employee_departments", "department_id"},
+		{"employee_positions", "position_id"},
+	} {
+		if err := normalizeJunction(db, junction.table, junction.column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeJunction chuẩn hóa các bản ghi đang hiệu lực của một bảng nối
+func normalizeJunction(db *gorm.DB, table, column string) error {
+	var rows []struct {
+		EmployeeID        uint
+		ReferenceID       uint
+		IsPrimary         bool
+		AllocationPercent int
+	}
+	if err := db.Table(table).
+		Select("employee_id, " + column + " AS reference_id, is_primary, allocation_percent").
+		Where("end_date IS NULL").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	type membership struct {
+		referenceID uint
+		allocation  int
+	}
+	byEmployee := map[uint][]membership{}
+	hasPrimary := map[uint]bool{}
+	for _, row := range rows {
+		byEmployee[row.EmployeeID] = append(byEmployee[row.EmployeeID], membership{row.ReferenceID, row.AllocationPercent})
+		hasPrimary[row.EmployeeID] = hasPrimary[row.EmployeeID] || row.IsPrimary
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for employeeID, memberships := range byEmployee {
+			sort.Slice(memberships, func(i, j int) bool { return memberships[i].referenceID < memberships[j].referenceID })
+			current := tx.Table(table).Where("employee_id = ? AND end_date IS NULL", employeeID)
+
+			if !hasPrimary[employeeID] {
+				if err := current.Session(&gorm.Session{}).Where(column+" = ?", memberships[0].referenceID).
+					Update("is_primary", true).Error; err != nil {
+					return err
+				}
+			}
+
+			total := 0
+			for _, m := range memberships {
+				total += m.allocation
+			}
+			if total <= 100 {
+				continue
+			}
+			share := 100 / len(memberships)
+			for i, m := range memberships {
+				allocation := share
+				if i == 0 {
+					allocation += 100 - share*len(memberships)
+				}
+				if err := current.Session(&gorm.Session{}).Where(column+" = ?", m.referenceID).
+					Update("allocation_percent", allocation).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,35 @@
+// Package migrations chứa các bước chuyển đổi dữ liệu mà AutoMigrate không tự làm được.
+// Mỗi bước phải chạy lại được nhiều lần mà không làm thay đổi dữ liệu đã chuyển đổi.
+package migrations
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// migration là một bước chuyển đổi dữ liệu có tên
+type migration struct {
+	name string
+	run  func(db *gorm.DB) error
+}
+
+// all liệt kê các bước theo thứ tự thực hiện
+var all = []migration{
+	{name: "backfill salary period", run: backfillSalaryPeriod},
+	{name: "seed leave types", run: seedLeaveTypes},
+	{name: "normalize work assignment status", run: normalizeWorkAssignmentStatus},
+	{name: "normalize memberships", run: normalizeMemberships},
+	{name: "move attachments to document storage", run: moveAttachments},
+}
+
+// Run chạy lần lượt các bước chuyển đổi sau khi AutoMigrate đã tạo cột mới
+func Run(db *gorm.DB) error {
+	for _, m := range all {
+		if err := m.run(db); err != nil {
+			log.Printf("Migration %q failed: %v", m.name, err)
+			return err
+		}
+	}
+	return nil
+}
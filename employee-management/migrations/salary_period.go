@@ -0,0 +1,89 @@
+package migrations
+
+import (
+	"employee-management/config"
+	"employee-management/models"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// defaultBackfillCutoffDay là số ngày đầu tháng mặc định mà bảng lương lập trong đó thuộc kỳ tháng trước
+const defaultBackfillCutoffDay = 10
+
+// backfillCutoffDay đọc PAYROLL_BACKFILL_CUTOFF_DAY, mặc định là defaultBackfillCutoffDay.
+// Giá trị sai làm dừng chuyển đổi để không gán nhầm kỳ lương cho dữ liệu cũ.
+func backfillCutoffDay() (int, error) {
+	value := config.GetEnv("PAYROLL_BACKFILL_CUTOFF_DAY")
+	if value == "" {
+		return defaultBackfillCutoffDay, nil
+	}
+	cutoffDay, err := strconv.Atoi(value)
+	if err != nil || cutoffDay < 0 || cutoffDay > 28 {
+		return 0, fmt.Errorf("PAYROLL_BACKFILL_CUTOFF_DAY must be a day between 0 and 28, got %q", value)
+	}
+	return cutoffDay, nil
+}
+
+// backfillSalaryPeriod gán kỳ lương cho các bảng lương cũ dựa trên ngày tạo.
+// Bảng lương tạo trong PAYROLL_BACKFILL_CUTOFF_DAY ngày đầu tháng được tính cho tháng trước,
+// vì lương tháng thường được lập vào đầu tháng kế tiếp. Nếu một nhân viên có nhiều bảng lương
+// chính thức trong cùng kỳ, bảng lập sớm nhất được giữ là chính thức, các bảng còn lại
+// chuyển thành lương bổ sung để có thể tạo chỉ mục duy nhất.
+func backfillSalaryPeriod(db *gorm.DB) error {
+	cutoffDay, err := backfillCutoffDay()
+	if err != nil {
+		return err
+	}
+
+	var salaries []models.Salary
+	if err := db.Where("period_year = 0 OR period_month = 0").Order("created_at, id").Find(&salaries).Error; err != nil {
+		return err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, salary := range salaries {
+			period := salary.CreatedAt
+			if period.Day() <= cutoffDay {
+				period = period.AddDate(0, 0, -period.Day())
+			}
+			start, end := models.PeriodBounds(period.Year(), int(period.Month()))
+
+			salaryType := salary.Type
+			if salaryType == "" {
+				salaryType = models.SalaryRegular
+			}
+			if salaryType == models.SalaryRegular {
+				var count int64
+				if err := tx.Model(&models.Salary{}).
+					Where("employee_id = ? AND period_year = ? AND period_month = ? AND type = ? AND id <> ?",
+						salary.EmployeeID, start.Year(), int(start.Month()), models.SalaryRegular, salary.ID).
+					Count(&count).Error; err != nil {
+					return err
+				}
+				if count > 0 {
+					salaryType = models.SalarySupplementary
+				}
+			}
+
+			if err := tx.Model(&models.Salary{}).Where("id = ?", salary.ID).Updates(map[string]interface{}{
+				"type":         salaryType,
+				"period_year":  start.Year(),
+				"period_month": int(start.Month()),
+				"period_start": start,
+				"period_end":   end,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Mỗi nhân viên chỉ có một bảng lương chính thức trong một kỳ
+	return db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_salaries_regular_period
+		ON salaries (employee_id, period_year, period_month) WHERE type = 'regular'`).Error
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"employee-management/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// legacyCompletedStatuses là các giá trị trạng thái tự do cũ được hiểu là đã hoàn thành
+var legacyCompletedStatuses = []string{"Hoàn thành", "Đã hoàn thành"}
+
+// normalizeWorkAssignmentStatus chuyển trạng thái tự do cũ của công tác sang các trạng thái mới.
+// Công tác đã hoàn thành giữ thời điểm cập nhật cuối làm thời điểm hoàn thành, các công tác
+// còn lại là planned hoặc in_progress tùy ngày bắt đầu; quá hạn sẽ do tác vụ định kỳ đánh dấu.
+func normalizeWorkAssignmentStatus(db *gorm.DB) error {
+	known := []string{
+		models.AssignmentPlanned,
+		models.AssignmentInProgress,
+		models.AssignmentCompleted,
+		models.AssignmentCancelled,
+		models.AssignmentOverdue,
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.WorkAssignment{}).
+			Where("status IN ?", legacyCompletedStatuses).
+			Updates(map[string]interface{}{
+				"status":       models.AssignmentCompleted,
+				"progress":     100,
+				"completed_at": gorm.Expr("updated_at"),
+			}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.WorkAssignment{}).
+			Where("status NOT IN ? AND start_date <= ?", known, time.Now()).
+			Update("status", models.AssignmentInProgress).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.WorkAssignment{}).
+			Where("status NOT IN ?", known).
+			Update("status", models.AssignmentPlanned).Error
+	})
+}
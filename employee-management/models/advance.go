@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Trạng thái riêng của khoản tạm ứng sau khi được duyệt
+const (
+	AdvanceDisbursed = "disbursed" // Đã chi tiền, đang trừ dần vào lương
+	AdvanceRepaid    = "repaid"    // Đã trừ hết vào lương
+)
+
+// SalaryAdvance là khoản tạm ứng lương của nhân viên, được trừ dần vào các kỳ lương sau
+type SalaryAdvance struct {
+	ID            uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	EmployeeID    uint       `json:"employee_id" gorm:"not null;index"`
+	Amount        int        `json:"amount" gorm:"not null"`
+	Installments  int        `json:"installments" gorm:"not null;default:1"` // Số kỳ lương trừ dần
+	Reason        string     `json:"reason"`
+	Status        string     `json:"status" gorm:"not null;default:'pending'"`
+	ReviewedByID  *uint      `json:"reviewed_by_id"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	ReviewComment string     `json:"review_comment"`
+	// Thông tin chi tiền và kỳ lương bắt đầu trừ
+	DisbursedByID         *uint              `json:"disbursed_by_id"`
+	DisbursedAt           *time.Time         `json:"disbursed_at"`
+	DisbursementReference string             `json:"disbursement_reference"`
+	FirstDeductionYear    int                `json:"first_deduction_year"`
+	FirstDeductionMonth   int                `json:"first_deduction_month"`
+	RepaidAmount          int                `json:"repaid_amount" gorm:"not null;default:0"`
+	CreatedAt             time.Time          `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt             time.Time          `json:"updated_at" gorm:"autoUpdateTime"`
+	Repayments            []AdvanceRepayment `json:"repayments,omitempty" gorm:"foreignKey:SalaryAdvanceID"`
+}
+
+// AdvanceRepayment là một lần trừ tạm ứng vào bảng lương
+type AdvanceRepayment struct {
+	ID              uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	SalaryAdvanceID uint      `json:"salary_advance_id" gorm:"not null;index"`
+	SalaryID        uint      `json:"salary_id" gorm:"not null;index"`
+	Amount          int       `json:"amount" gorm:"not null"`
+	PeriodYear      int       `json:"period_year"`
+	PeriodMonth     int       `json:"period_month"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Trạng thái chấm công trong ngày
+const (
+	AttendanceCheckedIn = "checked_in" // Đã vào ca, chưa ra ca
+	AttendancePresent   = "present"    // Đã vào và ra ca
+)
+
+// AttendanceRecord là bản ghi chấm công một ngày của nhân viên
+type AttendanceRecord struct {
+	ID                uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	EmployeeID        uint       `json:"employee_id" gorm:"not null;uniqueIndex:idx_attendance_employee_date"`
+	Date              CustomTime `json:"date" gorm:"type:date;not null;uniqueIndex:idx_attendance_employee_date"`
+	CheckIn           *time.Time `json:"check_in"`
+	CheckOut          *time.Time `json:"check_out"`
+	LateMinutes       int        `json:"late_minutes" gorm:"not null;default:0"`
+	EarlyLeaveMinutes int        `json:"early_leave_minutes" gorm:"not null;default:0"`
+	Status            string     `json:"status" gorm:"not null"`
+	Corrected         bool       `json:"corrected" gorm:"not null;default:false"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// AttendanceCorrection ghi lại một lần quản lý chỉnh sửa giờ chấm công
+type AttendanceCorrection struct {
+	ID                 uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	AttendanceRecordID uint       `json:"attendance_record_id" gorm:"not null;index"`
+	OldCheckIn         *time.Time `json:"old_check_in"`
+	OldCheckOut        *time.Time `json:"old_check_out"`
+	NewCheckIn         *time.Time `json:"new_check_in"`
+	NewCheckOut        *time.Time `json:"new_check_out"`
+	Reason             string     `json:"reason" gorm:"not null"`
+	CorrectedByID      uint       `json:"corrected_by_id" gorm:"not null"`
+	CreatedAt          time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Phạm vi của lịch iCalendar
+const (
+	FeedScopeEmployee   = "employee"
+	FeedScopeDepartment = "department"
+)
+
+// CalendarFeed là đường dẫn lịch iCalendar có mã bí mật để đăng ký trên Outlook/Google Calendar.
+// Ai có mã đều đọc được lịch nên mã được thu hồi bằng cách xóa bản ghi.
+type CalendarFeed struct {
+	ID             uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Token          string     `json:"token" gorm:"size:64;not null;uniqueIndex"`
+	Scope          string     `json:"scope" gorm:"not null"`        // employee hoặc department
+	ReferenceID    uint       `json:"reference_id" gorm:"not null"` // ID nhân viên hoặc phòng ban
+	CreatedByID    uint       `json:"created_by_id" gorm:"not null;index"`
+	LastAccessedAt *time.Time `json:"last_accessed_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
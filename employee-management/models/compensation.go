@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Lý do điều chỉnh mức lương cơ bản
+const (
+	CompensationHire         = "hire"          // Mức lương khi tuyển dụng
+	CompensationPromotion    = "promotion"     // Thăng chức
+	CompensationAnnualReview = "annual_review" // Đánh giá định kỳ hàng năm
+	CompensationAdjustment   = "adjustment"    // Điều chỉnh khác
+)
+
+// CompensationRevision ghi lại một lần thay đổi mức lương cơ bản của nhân viên
+type CompensationRevision struct {
+	ID            uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	EmployeeID    uint       `json:"employee_id" gorm:"not null;index"`
+	BaseSalary    int        `json:"base_salary" gorm:"not null"`
+	EffectiveDate CustomTime `json:"effective_date" gorm:"not null"`
+	Reason        string     `json:"reason" gorm:"not null"`
+	Note          string     `json:"note"`
+	Status        string     `json:"status" gorm:"not null;default:'pending'"`
+	RequestedByID uint       `json:"requested_by_id"`
+	ReviewedByID  *uint      `json:"reviewed_by_id"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	ReviewComment string     `json:"review_comment"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
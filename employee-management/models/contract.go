@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Loại hợp đồng lao động
+const (
+	ContractProbation  = "probation"  // Hợp đồng thử việc
+	ContractFixedTerm  = "fixed_term" // Hợp đồng xác định thời hạn
+	ContractIndefinite = "indefinite" // Hợp đồng không xác định thời hạn
+)
+
+// Trạng thái hợp đồng lao động
+const (
+	ContractActive     = "active"     // Đang hiệu lực
+	ContractRenewed    = "renewed"    // Đã được thay bằng hợp đồng gia hạn
+	ContractExpired    = "expired"    // Hết hạn mà không gia hạn
+	ContractTerminated = "terminated" // Chấm dứt trước hạn
+)
+
+// ContractExpiryAlertDays là các mốc (số ngày trước khi hết hạn) cần báo cho HR
+var ContractExpiryAlertDays = []int{30, 15, 7}
+
+// EmploymentContract là một hợp đồng lao động của nhân viên.
+// Hợp đồng không xác định thời hạn không có EndDate (thời gian rỗng).
+type EmploymentContract struct {
+	ID             uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	EmployeeID     uint       `json:"employee_id" gorm:"not null;index"`
+	ContractNumber string     `json:"contract_number"` // Số hợp đồng trên bản giấy
+	Type           string     `json:"type" gorm:"not null"`
+	StartDate      CustomTime `json:"start_date" gorm:"type:date;not null"`
+	EndDate        CustomTime `json:"end_date" gorm:"type:date"`
+	BaseSalary     int        `json:"base_salary" gorm:"not null"`
+	Status         string     `json:"status" gorm:"not null;default:'active';index"`
+	RenewalOfID    *uint      `json:"renewal_of_id" gorm:"index"` // Hợp đồng trước được gia hạn bằng hợp đồng này
+	Note           string     `json:"note"`
+	// Bản hợp đồng đã ký, lưu như giấy tờ loại contract của nhân viên
+	DocumentID   *uint      `json:"document_id"`
+	CreatedByID  uint       `json:"created_by_id"`
+	TerminatedAt *time.Time `json:"terminated_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ContractExpiryAlert ghi lại việc đã báo HR một hợp đồng sắp hết hạn ở một mốc, để không báo lặp lại
+type ContractExpiryAlert struct {
+	ID         uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ContractID uint       `json:"contract_id" gorm:"not null;uniqueIndex:idx_contract_expiry_alert"`
+	DaysBefore int        `json:"days_before" gorm:"not null;uniqueIndex:idx_contract_expiry_alert"`
+	EmployeeID uint       `json:"employee_id" gorm:"not null;index"`
+	ExpiresOn  CustomTime `json:"expires_on" gorm:"type:date"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// DocumentCategories là các loại giấy tờ được lưu cho nhân viên
+var DocumentCategories = []string{"id_card", "degree", "contract", "receipt", "other"}
+
+// Loại giấy tờ được các phân hệ khác đính kèm
+const (
+	DocumentContract = "contract" // Bản hợp đồng lao động đã ký
+	DocumentReceipt  = "receipt"  // Chứng từ chi phí công tác
+)
+
+// DocumentContentTypes là các định dạng tệp được chấp nhận, xác định từ nội dung tệp
+var DocumentContentTypes = []string{"application/pdf", "image/jpeg", "image/png", "image/webp"}
+
+// EmployeeDocument là một giấy tờ đính kèm của nhân viên, nội dung tệp nằm trong backend lưu trữ
+type EmployeeDocument struct {
+	ID           uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	EmployeeID   uint      `json:"employee_id" gorm:"not null;index"`
+	Category     string    `json:"category" gorm:"not null;index"`
+	FileName     string    `json:"file_name" gorm:"not null"` // Tên tệp gốc khi tải lên
+	ContentType  string    `json:"content_type" gorm:"not null"`
+	Size         int64     `json:"size" gorm:"not null"`
+	Checksum     string    `json:"checksum" gorm:"not null"` // SHA-256 của nội dung, dạng hex
+	StorageKey   string    `json:"-" gorm:"not null"`
+	Description  string    `json:"description"`
+	UploadedByID uint      `json:"uploaded_by_id"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// DocumentStorageKey tạo khóa lưu trữ mới cho một giấy tờ của nhân viên, ext là phần mở rộng kèm dấu chấm
+func DocumentStorageKey(employeeID uint, ext string) string {
+	return fmt.Sprintf("employees/%d/%d%s", employeeID, time.Now().UnixNano(), ext)
+}
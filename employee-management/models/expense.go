@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Trạng thái và loại riêng của chi phí công tác
+const (
+	ExpenseReimbursed = "reimbursed" // Đã hoàn trả qua bảng lương
+	ExpensePerDiem    = "per_diem"   // Phụ cấp công tác theo ngày, tính từ PerDiemRule
+	DefaultCurrency   = "VND"
+)
+
+// Loại chi phí công tác nhân viên được đề nghị hoàn trả
+var ExpenseCategories = []string{"transport", "lodging", "meals", "other"}
+
+// PerDiemRule là mức phụ cấp công tác theo ngày cho một điểm đến.
+// Chuyến công tác dài từ MinDays ngày trở lên áp dụng mức có MinDays lớn nhất phù hợp,
+// Destination để trống là mức mặc định cho mọi điểm đến.
+type PerDiemRule struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Destination string    `json:"destination" gorm:"uniqueIndex:idx_per_diem_rule"`
+	MinDays     int       `json:"min_days" gorm:"not null;default:1;uniqueIndex:idx_per_diem_rule"`
+	DailyAmount int       `json:"daily_amount" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ExpenseClaim là khoản chi phí công tác nhân viên đề nghị hoàn trả
+type ExpenseClaim struct {
+	ID               uint    `json:"id" gorm:"primaryKey;autoIncrement"`
+	WorkAssignmentID uint    `json:"work_assignment_id" gorm:"not null;index"`
+	EmployeeID       uint    `json:"employee_id" gorm:"not null;index"`
+	Category         string  `json:"category" gorm:"not null"` // Một trong ExpenseCategories hoặc per_diem
+	Description      string  `json:"description"`
+	Amount           float64 `json:"amount" gorm:"not null"`
+	Currency         string  `json:"currency" gorm:"not null;default:'VND'"`
+	ExchangeRate     float64 `json:"exchange_rate" gorm:"not null;default:1"` // Tỷ giá quy đổi sang VND
+	AmountVND        int     `json:"amount_vnd" gorm:"not null"`
+	CreatedByID      uint    `json:"created_by_id"` // Người lập khoản chi phí, có thể là quản lý lập thay nhân viên
+	// Chứng từ đính kèm, lưu như giấy tờ loại receipt của nhân viên
+	ReceiptDocumentID *uint      `json:"receipt_document_id"`
+	Status            string     `json:"status" gorm:"not null;default:'pending'"`
+	ReviewedByID      *uint      `json:"reviewed_by_id"`
+	ReviewedAt        *time.Time `json:"reviewed_at"`
+	ReviewComment     string     `json:"review_comment"`
+	SalaryID          *uint      `json:"salary_id" gorm:"index"` // Bảng lương đã hoàn trả khoản này
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Trạng thái vị trí tuyển dụng
+const (
+	VacancyOpen      = "open"      // Đang tuyển
+	VacancyFilled    = "filled"    // Đã có người nhận việc
+	VacancyCancelled = "cancelled" // Hủy tuyển
+)
+
+// HeadcountPlan là định biên được duyệt cho một chức vụ trong phòng ban
+type HeadcountPlan struct {
+	ID           uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	DepartmentID uint      `json:"department_id" gorm:"not null;uniqueIndex:idx_headcount_plan"`
+	PositionID   uint      `json:"position_id" gorm:"not null;uniqueIndex:idx_headcount_plan"`
+	PlannedCount int       `json:"planned_count" gorm:"not null;default:0"`
+	Note         string    `json:"note"`
+	UpdatedByID  uint      `json:"updated_by_id"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Vacancy là một vị trí đang trống trong định biên, được đưa sang quy trình tuyển dụng
+type Vacancy struct {
+	ID           uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	DepartmentID uint   `json:"department_id" gorm:"not null;index"`
+	PositionID   uint   `json:"position_id" gorm:"not null;index"`
+	Title        string `json:"title" gorm:"not null"`
+	Description  string `json:"description"`
+	Status       string `json:"status" gorm:"not null;default:'open';index"`
+	// Mã của vị trí trong hệ thống tuyển dụng bên ngoài, nếu có
+	RecruitmentReference string     `json:"recruitment_reference"`
+	TargetStartDate      CustomTime `json:"target_start_date"`
+	OpenedByID           uint       `json:"opened_by_id"`
+	FilledByEmployeeID   *uint      `json:"filled_by_employee_id"`
+	ClosedAt             *time.Time `json:"closed_at"`
+	CreatedAt            time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt            time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
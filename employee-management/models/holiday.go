@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Loại ngày nghỉ lễ
+const (
+	HolidayStatutory  = "statutory"  // Ngày lễ theo luật
+	HolidaySubstitute = "substitute" // Ngày nghỉ bù
+	HolidayCompany    = "company"    // Ngày nghỉ riêng của công ty
+)
+
+// Holiday là một ngày nghỉ có hưởng lương trong lịch của công ty
+type Holiday struct {
+	ID        uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Date      CustomTime `json:"date" gorm:"type:date;not null;uniqueIndex"`
+	Year      int        `json:"year" gorm:"not null;index"`
+	Name      string     `json:"name" gorm:"not null"`
+	Type      string     `json:"type" gorm:"not null"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
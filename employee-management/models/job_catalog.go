@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// JobFamily là nhóm nghề gom các chức vụ cùng chuyên môn, ví dụ Kỹ thuật, Kinh doanh
+type JobFamily struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"unique;not null"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// PayGrade là bậc lương với khung lương cơ bản tối thiểu, trung điểm và tối đa
+type PayGrade struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Code      string    `json:"code" gorm:"unique;not null"`
+	Name      string    `json:"name"`
+	MinSalary int       `json:"min_salary" gorm:"not null"`
+	MidSalary int       `json:"mid_salary" gorm:"not null"`
+	MaxSalary int       `json:"max_salary" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Trạng thái riêng của đơn nghỉ phép, ngoài pending/approved/rejected
+const LeaveCancelled = "cancelled"
+
+// LeaveType là loại nghỉ phép và chính sách cộng dồn ngày phép
+type LeaveType struct {
+	ID                  uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Code                string    `json:"code" gorm:"unique;not null"`
+	Name                string    `json:"name" gorm:"not null"`
+	Paid                bool      `json:"paid"`                  // Ngày nghỉ có được tính lương hay không
+	AnnualDays          float64   `json:"annual_days"`           // 0 nghĩa là không giới hạn số dư
+	MonthlyAccrual      bool      `json:"monthly_accrual"`       // Cộng dần theo tháng thay vì cấp đủ từ đầu năm
+	SeniorityBonusDays  float64   `json:"seniority_bonus_days"`  // Số ngày cộng thêm mỗi mốc thâm niên
+	SeniorityEveryYears int       `json:"seniority_every_years"` // Độ dài một mốc thâm niên (năm)
+	MaxCarryOverDays    float64   `json:"max_carry_over_days"`   // Số ngày tối đa chuyển sang năm sau
+	CreatedAt           time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// LeaveRequest là đơn xin nghỉ phép của nhân viên
+type LeaveRequest struct {
+	ID            uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	EmployeeID    uint       `json:"employee_id" gorm:"not null;index"`
+	LeaveTypeID   uint       `json:"leave_type_id" gorm:"not null"`
+	StartDate     CustomTime `json:"start_date" gorm:"type:date;not null"`
+	EndDate       CustomTime `json:"end_date" gorm:"type:date;not null"`
+	HalfDay       bool       `json:"half_day"` // Nghỉ nửa ngày, chỉ áp dụng khi nghỉ một ngày
+	Days          float64    `json:"days" gorm:"not null"`
+	Reason        string     `json:"reason"`
+	Status        string     `json:"status" gorm:"not null;default:'pending'"`
+	ReviewedByID  *uint      `json:"reviewed_by_id"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	ReviewComment string     `json:"review_comment"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	LeaveType     LeaveType  `json:"leave_type" gorm:"foreignKey:LeaveTypeID"`
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // ResponseMessage đại diện cho phản hồi chung
@@ -39,6 +41,11 @@ func (ct CustomTime) Value() (driver.Value, error) {
 
 // Implement Scanner interface để lấy CustomTime từ cơ sở dữ liệu
 func (ct *CustomTime) Scan(value interface{}) error {
+	// Cột không có giá trị (NULL) được hiểu là thời gian rỗng
+	if value == nil {
+		ct.Time = time.Time{}
+		return nil
+	}
 	t, ok := value.(time.Time)
 	if !ok {
 		return fmt.Errorf("cannot scan type %T into CustomTime", value)
@@ -60,6 +67,11 @@ type Employee struct {
 	Role                string               `json:"role" gorm:"not null"`
 	Status              string               `json:"status" gorm:"not null"`
 	Gender              string               `json:"gender"`
+	HireDate            CustomTime           `json:"hire_date" gorm:"type:date"` // Ngày vào làm, dùng để tính thâm niên
+	BankCode            string               `json:"bank_code"`                  // Mã ngân hàng, ví dụ "VCB", "BIDV"
+	BankAccountNumber   string               `json:"bank_account_number"`        // Số tài khoản nhận lương
+	BankAccountHolder   string               `json:"bank_account_holder"`        // Tên chủ tài khoản
+	ManagerID           *uint                `json:"manager_id" gorm:"index"`    // Quản lý trực tiếp
 	CreatedAt           time.Time            `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt           time.Time            `json:"updated_at" gorm:"autoUpdateTime"`
 	DepartmentIDs       []uint               `json:"department_ids" gorm:"-"` // Không lưu vào database
@@ -73,7 +85,11 @@ type Department struct {
 	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
 	Name        string     `json:"name" gorm:"not null"`
 	Description string     `json:"description"`
+	ParentID    *uint      `json:"parent_id" gorm:"index"` // Phòng ban cấp trên, nil là cấp cao nhất (khối)
+	HeadID      *uint      `json:"head_id"`                // Trưởng phòng ban
 	Employees   []Employee `gorm:"many2many:employee_departments" json:"employees"`
+	// Phòng ban đã xóa được giữ lại để lịch sử phòng ban của nhân viên vẫn còn tên
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 // Position đại diện cho một chức vụ trong tổ chức
@@ -81,7 +97,12 @@ type Position struct {
 	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
 	Title       string     `json:"title" gorm:"not null"`
 	Description string     `json:"description"`
+	JobFamilyID *uint      `json:"job_family_id" gorm:"index"`      // Nhóm nghề, ví dụ Kỹ thuật, Kinh doanh
+	Level       int        `json:"level" gorm:"not null;default:0"` // Cấp bậc trong nhóm nghề, số lớn hơn là cấp cao hơn
+	PayGradeID  *uint      `json:"pay_grade_id" gorm:"index"`       // Bậc lương quy định khung lương của chức vụ
 	Employees   []Employee `gorm:"many2many:employee_positions" json:"employees"`
+	// Chức vụ đã xóa được giữ lại để lịch sử chức vụ của nhân viên vẫn còn tên
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 // Salary đại diện cho bảng lương của nhân viên
@@ -96,21 +117,90 @@ type Salary struct {
 	TotalSalary  int       `json:"total_salary" gorm:"not null"`
 	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	WorkingDays  uint      `json:"working_days" gorm:"default:0"`
+	WorkingDays  float64   `json:"working_days" gorm:"default:0"` // Có thể lẻ nửa ngày khi nghỉ phép nửa ngày
 	Employee     Employee  `json:"employee" gorm:"foreignKey:EmployeeID"`
 	Status       string    `json:"status" gorm:"not null;default:'unpaid'"`
+	// Trạng thái phê duyệt: draft -> prepared -> reviewed -> approved
+	ApprovalStatus string           `json:"approval_status" gorm:"not null;default:'draft'"`
+	Approvals      []SalaryApproval `json:"approvals,omitempty" gorm:"foreignKey:SalaryID"`
+	// Mã tham chiếu trong file chuyển khoản ngân hàng và thời điểm thanh toán
+	PaymentReference string     `json:"payment_reference" gorm:"index"`
+	PaidAt           *time.Time `json:"paid_at"`
+	// Kỳ lương mà bảng lương thuộc về, không phụ thuộc ngày tạo
+	Type        string     `json:"type" gorm:"not null;default:'regular'"`
+	PeriodYear  int        `json:"period_year" gorm:"not null;default:0;index:idx_salaries_period"`
+	PeriodMonth int        `json:"period_month" gorm:"not null;default:0;index:idx_salaries_period"`
+	PeriodStart CustomTime `json:"period_start"`
+	PeriodEnd   CustomTime `json:"period_end"`
+	// Tiền làm thêm giờ, tổng hợp từ các dòng thu nhập OT
+	OvertimePay  int                 `json:"overtime_pay" gorm:"not null;default:0"`
+	EarningLines []SalaryEarningLine `json:"earning_lines,omitempty" gorm:"foreignKey:SalaryID"`
+	// Số tiền tạm ứng được trừ trong kỳ
+	AdvanceDeduction  int                `json:"advance_deduction" gorm:"not null;default:0"`
+	AdvanceRepayments []AdvanceRepayment `json:"advance_repayments,omitempty" gorm:"foreignKey:SalaryID"`
+	// Hoàn trả chi phí công tác, không chịu thuế
+	Reimbursement int `json:"reimbursement" gorm:"not null;default:0"`
+}
+
+// Loại bảng lương: mỗi nhân viên chỉ có một bảng lương chính thức cho mỗi kỳ
+const (
+	SalaryRegular       = "regular"       // Lương chính thức của kỳ
+	SalarySupplementary = "supplementary" // Lương bổ sung, truy lĩnh hoặc điều chỉnh
+)
+
+// PeriodBounds trả về ngày đầu và ngày cuối của kỳ lương tháng
+func PeriodBounds(year, month int) (time.Time, time.Time) {
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(0, 1, -1)
+}
+
+// Trạng thái thanh toán của bảng lương
+const (
+	SalaryUnpaid = "Chưa thanh toán"
+	SalaryPaid   = "Đã thanh toán"
+)
+
+// Các bước trong quy trình phê duyệt bảng lương
+const (
+	SalaryDraft    = "draft"    // Bảng lương nháp, có thể chỉnh sửa
+	SalaryPrepared = "prepared" // Đã lập bởi bộ phận Payroll
+	SalaryReviewed = "reviewed" // Đã được trưởng phòng nhân sự xem xét
+	SalaryApproved = "approved" // Đã được bộ phận tài chính phê duyệt
+)
+
+// SalaryApproval ghi lại một lần chuyển trạng thái phê duyệt của bảng lương
+type SalaryApproval struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	SalaryID   uint      `json:"salary_id" gorm:"not null;index"`
+	Action     string    `json:"action" gorm:"not null"`
+	FromStatus string    `json:"from_status" gorm:"not null"`
+	ToStatus   string    `json:"to_status" gorm:"not null"`
+	ActorID    uint      `json:"actor_id" gorm:"not null"`
+	ActorName  string    `json:"actor_name" gorm:"not null"`
+	Comment    string    `json:"comment"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
 }
 
-// EmployeeDepartment đại diện cho quan hệ giữa nhân viên và phòng ban
+// EmployeeDepartment đại diện cho quan hệ giữa nhân viên và phòng ban trong một khoảng thời gian.
+// Khi điều chuyển, bản ghi cũ được đóng bằng EndDate và một bản ghi mới được mở.
 type EmployeeDepartment struct {
-	EmployeeID   uint `json:"employee_id"`
-	DepartmentID uint `json:"department_id"`
+	EmployeeID   uint       `json:"employee_id" gorm:"index"`
+	DepartmentID uint       `json:"department_id" gorm:"index"`
+	StartDate    *time.Time `json:"start_date" gorm:"type:date"` // nil với dữ liệu cũ chưa rõ ngày bắt đầu
+	EndDate      *time.Time `json:"end_date" gorm:"type:date"`   // Ngày cuối cùng thuộc phòng ban, nil khi còn hiệu lực
+	IsPrimary    bool       `json:"is_primary" gorm:"not null;default:false"`
+	// Tỉ lệ thời gian làm việc cho phòng ban, tổng các phòng ban đang hiệu lực không vượt quá 100
+	AllocationPercent int `json:"allocation_percent" gorm:"not null;default:100"`
 }
 
-// EmployeePosition đại diện cho quan hệ giữa nhân viên và chức vụ
+// EmployeePosition đại diện cho quan hệ giữa nhân viên và chức vụ trong một khoảng thời gian
 type EmployeePosition struct {
-	EmployeeID uint `json:"employee_id"`
-	PositionID uint `json:"position_id"`
+	EmployeeID        uint       `json:"employee_id" gorm:"index"`
+	PositionID        uint       `json:"position_id" gorm:"index"`
+	StartDate         *time.Time `json:"start_date" gorm:"type:date"`
+	EndDate           *time.Time `json:"end_date" gorm:"type:date"`
+	IsPrimary         bool       `json:"is_primary" gorm:"not null;default:false"`
+	AllocationPercent int        `json:"allocation_percent" gorm:"not null;default:100"`
 }
 
 // WorkAssignment đại diện cho bảng công tác của nhân viên
@@ -119,10 +209,45 @@ type WorkAssignment struct {
 	EmployeeID   uint       `json:"employee_id" gorm:"not null"`
 	EmployeeName string     `json:"employee_name" gorm:"not null"`
 	Assignment   string     `json:"assignment" gorm:"not null"`
+	Destination  string     `json:"destination"` // Điểm đến, dùng để tính phụ cấp công tác
 	StartDate    CustomTime `json:"start_date" gorm:"not null"`
 	EndDate      CustomTime `json:"end_date"`
-	Status       string     `json:"status" gorm:"not null;default:'Chưa hoàn thành'"`
+	Status       string     `json:"status" gorm:"not null;default:'planned'"`
 	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
-	Employee     Employee   `json:"employee" gorm:"foreignKey:EmployeeID"`
+	Employee     Employee   `json:"-" gorm:"foreignKey:EmployeeID"` // API trả về bản rút gọn thay cho toàn bộ hồ sơ
+	// Tiến độ thực hiện, chỉ thay đổi qua các thao tác chuyển trạng thái
+	Progress    int                   `json:"progress" gorm:"not null;default:0"` // Phần trăm hoàn thành (0-100)
+	Notes       string                `json:"notes"`
+	CompletedAt *time.Time            `json:"completed_at"`
+	History     []WorkAssignmentEvent `json:"history,omitempty" gorm:"foreignKey:WorkAssignmentID"`
 }
+
+// Trạng thái của công tác
+const (
+	AssignmentPlanned    = "planned"
+	AssignmentInProgress = "in_progress"
+	AssignmentCompleted  = "completed"
+	AssignmentCancelled  = "cancelled"
+	AssignmentOverdue    = "overdue" // Tự động gán khi quá EndDate mà chưa hoàn thành
+)
+
+// WorkAssignmentEvent ghi lại một lần chuyển trạng thái hoặc cập nhật tiến độ công tác
+type WorkAssignmentEvent struct {
+	ID               uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	WorkAssignmentID uint      `json:"work_assignment_id" gorm:"not null;index"`
+	Action           string    `json:"action" gorm:"not null"`
+	FromStatus       string    `json:"from_status"`
+	ToStatus         string    `json:"to_status"`
+	Progress         int       `json:"progress"`
+	Note             string    `json:"note"`
+	ActorID          *uint     `json:"actor_id"` // Để trống khi hệ thống tự chuyển trạng thái
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Trạng thái duyệt dùng chung cho các loại yêu cầu (điều chỉnh lương, nghỉ phép, tăng ca...)
+const (
+	RequestPending  = "pending"
+	RequestApproved = "approved"
+	RequestRejected = "rejected"
+)
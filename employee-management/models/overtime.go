@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// OvertimeRequest là đơn đăng ký làm thêm giờ, phải được duyệt trước khi làm.
+// Sau khi làm, số giờ thực tế được ghi nhận thành các OvertimeEntry.
+type OvertimeRequest struct {
+	ID            uint            `json:"id" gorm:"primaryKey;autoIncrement"`
+	EmployeeID    uint            `json:"employee_id" gorm:"not null;index"`
+	Date          CustomTime      `json:"date" gorm:"type:date;not null"`
+	StartTime     string          `json:"start_time" gorm:"not null"` // HH:MM
+	EndTime       string          `json:"end_time" gorm:"not null"`   // HH:MM, nhỏ hơn StartTime nếu qua nửa đêm
+	PlannedHours  float64         `json:"planned_hours" gorm:"not null"`
+	RecordedHours float64         `json:"recorded_hours"`
+	Recorded      bool            `json:"recorded"`
+	Reason        string          `json:"reason"`
+	Status        string          `json:"status" gorm:"not null;default:'pending'"`
+	ReviewedByID  *uint           `json:"reviewed_by_id"`
+	ReviewedAt    *time.Time      `json:"reviewed_at"`
+	ReviewComment string          `json:"review_comment"`
+	CreatedAt     time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+	Entries       []OvertimeEntry `json:"entries,omitempty" gorm:"foreignKey:OvertimeRequestID"`
+}
+
+// OvertimeEntry là số giờ làm thêm thực tế theo loại ngày (weekday/weekend/holiday) và ca đêm
+type OvertimeEntry struct {
+	ID                uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	OvertimeRequestID uint       `json:"overtime_request_id" gorm:"not null;index"`
+	EmployeeID        uint       `json:"employee_id" gorm:"not null;index"`
+	Date              CustomTime `json:"date" gorm:"type:date;not null"`
+	Category          string     `json:"category" gorm:"not null"`
+	Night             bool       `json:"night"`
+	Hours             float64    `json:"hours" gorm:"not null"`
+	SalaryID          *uint      `json:"salary_id" gorm:"index"` // Bảng lương đã trả số giờ này
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// SalaryEarningLine là một dòng thu nhập được tính tự động trên bảng lương
+type SalaryEarningLine struct {
+	ID          uint    `json:"id" gorm:"primaryKey;autoIncrement"`
+	SalaryID    uint    `json:"salary_id" gorm:"not null;index"`
+	Type        string  `json:"type" gorm:"not null"`
+	Category    string  `json:"category"`
+	Night       bool    `json:"night"`
+	Hours       float64 `json:"hours"`
+	Multiplier  float64 `json:"multiplier"`
+	Amount      int     `json:"amount" gorm:"not null"`
+	Description string  `json:"description"`
+	NonTaxable  bool    `json:"non_taxable"` // Khoản không tính thuế thu nhập, ví dụ hoàn trả chi phí
+}
+
+// Loại dòng thu nhập trên bảng lương
+const (
+	EarningOvertime      = "overtime"
+	EarningReimbursement = "reimbursement"
+)
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// ShiftTemplate là mẫu ca làm việc (giờ bắt đầu, kết thúc, thời gian nghỉ)
+type ShiftTemplate struct {
+	ID           uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name         string    `json:"name" gorm:"unique;not null"`
+	StartTime    string    `json:"start_time" gorm:"not null"` // HH:MM
+	EndTime      string    `json:"end_time" gorm:"not null"`   // HH:MM, nhỏ hơn StartTime nếu ca qua đêm
+	BreakMinutes int       `json:"break_minutes" gorm:"not null;default:0"`
+	NightShift   bool      `json:"night_shift"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ShiftAssignment là việc xếp một nhân viên vào một ca trong ngày của phòng ban
+type ShiftAssignment struct {
+	ID              uint          `json:"id" gorm:"primaryKey;autoIncrement"`
+	DepartmentID    uint          `json:"department_id" gorm:"not null;index"`
+	EmployeeID      uint          `json:"employee_id" gorm:"not null;uniqueIndex:idx_shift_employee_date"`
+	Date            CustomTime    `json:"date" gorm:"type:date;not null;uniqueIndex:idx_shift_employee_date"`
+	ShiftTemplateID uint          `json:"shift_template_id" gorm:"not null"`
+	CreatedAt       time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time     `json:"updated_at" gorm:"autoUpdateTime"`
+	ShiftTemplate   ShiftTemplate `json:"shift_template" gorm:"foreignKey:ShiftTemplateID"`
+}
+
+// ShiftSwapRequest là yêu cầu đổi ca giữa hai nhân viên.
+// Nhân viên được đề nghị đổi phải đồng ý trước khi quản lý duyệt.
+type ShiftSwapRequest struct {
+	ID                    uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	RequesterID           uint       `json:"requester_id" gorm:"not null;index"`
+	RequesterAssignmentID uint       `json:"requester_assignment_id" gorm:"not null"`
+	TargetEmployeeID      uint       `json:"target_employee_id" gorm:"not null;index"`
+	TargetAssignmentID    uint       `json:"target_assignment_id" gorm:"not null"`
+	Reason                string     `json:"reason"`
+	TargetAccepted        bool       `json:"target_accepted"`
+	Status                string     `json:"status" gorm:"not null;default:'pending'"`
+	ReviewedByID          *uint      `json:"reviewed_by_id"`
+	ReviewedAt            *time.Time `json:"reviewed_at"`
+	ReviewComment         string     `json:"review_comment"`
+	CreatedAt             time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt             time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,84 @@
+// Package orgchart xuất sơ đồ tổ chức dạng cây phòng ban sang Graphviz DOT và SVG.
+package orgchart
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Person là một nhân viên trên sơ đồ tổ chức
+type Person struct {
+	ID        uint     `json:"id"`
+	Name      string   `json:"name"`
+	Email     string   `json:"email"`
+	Positions []string `json:"positions"`
+}
+
+// Node là một phòng ban trên sơ đồ tổ chức kèm trưởng phòng, thành viên và phòng ban cấp dưới
+type Node struct {
+	ID       uint     `json:"id"`
+	Name     string   `json:"name"`
+	Head     *Person  `json:"head"`
+	Members  []Person `json:"members"`
+	Children []Node   `json:"children"`
+}
+
+// headLine là dòng trưởng phòng hiển thị trên sơ đồ
+func (n Node) headLine() string {
+	if n.Head == nil {
+		return "Head: (vacant)"
+	}
+	line := "Head: " + n.Head.Name
+	if len(n.Head.Positions) > 0 {
+		line += " (" + strings.Join(n.Head.Positions, ", ") + ")"
+	}
+	return line
+}
+
+// membersLine là dòng số thành viên hiển thị trên sơ đồ
+func (n Node) membersLine() string {
+	if len(n.Members) == 1 {
+		return "1 member"
+	}
+	return fmt.Sprintf("%d members", len(n.Members))
+}
+
+// escapeDOT thoát chuỗi dùng trong nhãn record của Graphviz
+func escapeDOT(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"{", `\{`,
+		"}", `\}`,
+		"|", `\|`,
+		"<", `\<`,
+		">", `\>`,
+		"\n", " ",
+	).Replace(value)
+}
+
+// WriteDOT ghi sơ đồ tổ chức theo ngôn ngữ Graphviz DOT, mỗi phòng ban là một nút dạng record
+func WriteDOT(w io.Writer, roots []Node) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph orgchart {")
+	fmt.Fprintln(bw, "  rankdir=TB;")
+	fmt.Fprintln(bw, `  node [shape=record, style="rounded,filled", fillcolor="#f5f7fa", fontname="Helvetica"];`)
+	fmt.Fprintln(bw, `  edge [arrowhead=none];`)
+
+	var walk func(node Node)
+	walk = func(node Node) {
+		fmt.Fprintf(bw, "  d%d [label=\"{%s|%s|%s}\"];\n", node.ID,
+			escapeDOT(node.Name), escapeDOT(node.headLine()), escapeDOT(node.membersLine()))
+		for _, child := range node.Children {
+			fmt.Fprintf(bw, "  d%d -> d%d;\n", node.ID, child.ID)
+			walk(child)
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
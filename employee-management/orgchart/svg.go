@@ -0,0 +1,133 @@
+package orgchart
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"unicode/utf8"
+)
+
+// Kích thước bố cục SVG (đơn vị pixel)
+const (
+	boxWidth   = 220
+	boxHeight  = 64
+	hGap       = 24
+	vGap       = 48
+	margin     = 20
+	lineHeight = 18
+	// maxLabelRunes là số ký tự tối đa của một dòng trong ô, dài hơn sẽ bị cắt
+	maxLabelRunes = 30
+)
+
+// box là vị trí của một phòng ban trên bản vẽ
+type box struct {
+	node     Node
+	x, y     int // Góc trên bên trái
+	children []*box
+}
+
+// subtreeWidth tính độ rộng cần cho một nhánh: tổng độ rộng các nhánh con hoặc độ rộng một ô
+func subtreeWidth(node Node) int {
+	if width := childrenWidth(node); width > boxWidth {
+		return width
+	}
+	return boxWidth
+}
+
+// layout đặt nhánh vào vùng bắt đầu từ left, ô cha nằm giữa các nhánh con
+func layout(node Node, left, depth int) (*box, int) {
+	width := subtreeWidth(node)
+	b := &box{node: node, x: left + (width-boxWidth)/2, y: margin + depth*(boxHeight+vGap)}
+	maxDepth := depth
+
+	childLeft := left + (width-childrenWidth(node))/2
+	for _, child := range node.Children {
+		childBox, childDepth := layout(child, childLeft, depth+1)
+		b.children = append(b.children, childBox)
+		childLeft += subtreeWidth(child) + hGap
+		if childDepth > maxDepth {
+			maxDepth = childDepth
+		}
+	}
+	return b, maxDepth
+}
+
+// childrenWidth là tổng độ rộng các nhánh con, không tính ô cha
+func childrenWidth(node Node) int {
+	width := 0
+	for i, child := range node.Children {
+		if i > 0 {
+			width += hGap
+		}
+		width += subtreeWidth(child)
+	}
+	return width
+}
+
+// truncate cắt nhãn dài để vừa ô
+func truncate(label string) string {
+	if utf8.RuneCountInString(label) <= maxLabelRunes {
+		return label
+	}
+	runes := []rune(label)
+	return string(runes[:maxLabelRunes-1]) + "…"
+}
+
+// WriteSVG vẽ sơ đồ tổ chức thành ảnh SVG, các cây gốc được đặt cạnh nhau
+func WriteSVG(w io.Writer, roots []Node) error {
+	var boxes []*box
+	left, maxDepth := margin, 0
+	for _, root := range roots {
+		b, depth := layout(root, left, 0)
+		boxes = append(boxes, b)
+		left += subtreeWidth(root) + hGap
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+	width := left - hGap + margin
+	if len(roots) == 0 {
+		width = 2*margin + boxWidth
+	}
+	height := 2*margin + (maxDepth+1)*boxHeight + maxDepth*vGap
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif">`+"\n", width, height, width, height)
+	fmt.Fprintln(bw, `<rect width="100%" height="100%" fill="#ffffff"/>`)
+
+	var draw func(b *box)
+	draw = func(b *box) {
+		// Đường nối từ cạnh dưới ô cha tới cạnh trên ô con, gấp khúc ở giữa khoảng trống
+		for _, child := range b.children {
+			fromX, fromY := b.x+boxWidth/2, b.y+boxHeight
+			toX, toY := child.x+boxWidth/2, child.y
+			midY := fromY + vGap/2
+			fmt.Fprintf(bw, `<path d="M%d %d V%d H%d V%d" fill="none" stroke="#8a94a6" stroke-width="1.5"/>`+"\n", fromX, fromY, midY, toX, toY)
+		}
+		fmt.Fprintf(bw, `<g><title>%s</title>`, html.EscapeString(b.node.Name))
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="#f5f7fa" stroke="#34495e"/>`, b.x, b.y, boxWidth, boxHeight)
+		lines := []struct {
+			text   string
+			size   int
+			weight string
+		}{
+			{b.node.Name, 14, "bold"},
+			{b.node.headLine(), 11, "normal"},
+			{b.node.membersLine(), 11, "normal"},
+		}
+		for i, line := range lines {
+			fmt.Fprintf(bw, `<text x="%d" y="%d" font-size="%d" font-weight="%s" text-anchor="middle" fill="#1f2d3d">%s</text>`,
+				b.x+boxWidth/2, b.y+lineHeight+i*lineHeight-2, line.size, line.weight, html.EscapeString(truncate(line.text)))
+		}
+		fmt.Fprintln(bw, "</g>")
+		for _, child := range b.children {
+			draw(child)
+		}
+	}
+	for _, b := range boxes {
+		draw(b)
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
@@ -0,0 +1,52 @@
+package payroll
+
+import (
+	"fmt"
+	"math"
+)
+
+// Vị trí của một mức lương so với khung lương
+const (
+	BandBelow  = "below"  // Thấp hơn mức tối thiểu
+	BandWithin = "within" // Trong khung
+	BandAbove  = "above"  // Cao hơn mức tối đa
+)
+
+// Band là khung lương cơ bản của một bậc lương
+type Band struct {
+	Min int
+	Mid int
+	Max int
+}
+
+// Validate kiểm tra khung lương dương và theo thứ tự tối thiểu <= trung điểm <= tối đa
+func (b Band) Validate() error {
+	if b.Min <= 0 {
+		return fmt.Errorf("min_salary must be positive")
+	}
+	if b.Mid < b.Min || b.Max < b.Mid {
+		return fmt.Errorf("Salary band must satisfy min_salary <= mid_salary <= max_salary")
+	}
+	return nil
+}
+
+// Position cho biết salary thấp hơn, nằm trong hay cao hơn khung lương
+func (b Band) Position(salary int) string {
+	switch {
+	case salary < b.Min:
+		return BandBelow
+	case salary > b.Max:
+		return BandAbove
+	default:
+		return BandWithin
+	}
+}
+
+// CompaRatio là tỉ lệ giữa salary và trung điểm khung lương, làm tròn 4 chữ số thập phân.
+// 1 nghĩa là đúng trung điểm; trả về 0 nếu khung lương không có trung điểm.
+func (b Band) CompaRatio(salary int) float64 {
+	if b.Mid <= 0 {
+		return 0
+	}
+	return math.Round(float64(salary)/float64(b.Mid)*10000) / 10000
+}
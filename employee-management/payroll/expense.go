@@ -0,0 +1,57 @@
+package payroll
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// PerDiemRate là mức phụ cấp công tác theo ngày cho một điểm đến.
+// Destination để trống là mức mặc định, MinDays là số ngày công tác tối thiểu để áp dụng.
+type PerDiemRate struct {
+	Destination string
+	MinDays     int
+	DailyAmount int
+}
+
+// TripDays đếm số ngày của chuyến công tác [start, end], chuyến không có ngày kết thúc tính một ngày
+func TripDays(start, end time.Time) int {
+	if end.IsZero() {
+		return 1
+	}
+	return DaysBetween(start, end)
+}
+
+// SelectPerDiemRate chọn mức phụ cấp cho chuyến công tác đến destination dài days ngày.
+// Mức của đúng điểm đến (không phân biệt hoa thường) được ưu tiên hơn mức mặc định;
+// trong cùng điểm đến, chọn mức có MinDays lớn nhất không vượt quá days.
+func SelectPerDiemRate(rates []PerDiemRate, destination string, days int) (PerDiemRate, bool) {
+	destination = strings.TrimSpace(destination)
+	for _, exact := range []bool{true, false} {
+		var best PerDiemRate
+		found := false
+		for _, rate := range rates {
+			if exact && (destination == "" || !strings.EqualFold(strings.TrimSpace(rate.Destination), destination)) {
+				continue
+			}
+			if !exact && strings.TrimSpace(rate.Destination) != "" {
+				continue
+			}
+			if rate.MinDays > days {
+				continue
+			}
+			if !found || rate.MinDays > best.MinDays {
+				best, found = rate, true
+			}
+		}
+		if found {
+			return best, true
+		}
+	}
+	return PerDiemRate{}, false
+}
+
+// ConvertAmount quy đổi số tiền theo tỷ giá và làm tròn đến đồng
+func ConvertAmount(amount, exchangeRate float64) int {
+	return int(math.Round(amount * exchangeRate))
+}
@@ -0,0 +1,85 @@
+package payroll
+
+import (
+	"math"
+	"time"
+)
+
+// LeavePolicy là chính sách cộng dồn ngày phép của một loại nghỉ phép
+type LeavePolicy struct {
+	AnnualDays          float64 // Số ngày phép mỗi năm, 0 nghĩa là không theo dõi số dư
+	MonthlyAccrual      bool    // true: cộng dần theo tháng, false: cấp đủ từ đầu năm
+	SeniorityBonusDays  float64 // Số ngày cộng thêm cho mỗi mốc thâm niên
+	SeniorityEveryYears int     // Độ dài một mốc thâm niên (năm)
+	MaxCarryOverDays    float64 // Số ngày tối đa được chuyển sang năm sau
+}
+
+// roundHalf làm tròn xuống đến 0.5 ngày
+func roundHalf(days float64) float64 {
+	return math.Floor(days*2) / 2
+}
+
+// ServiceYears tính số năm làm việc tròn tính đến ngày asOf
+func ServiceYears(hireDate, asOf time.Time) int {
+	years := asOf.Year() - hireDate.Year()
+	if asOf.YearDay() < hireDate.YearDay() {
+		years--
+	}
+	if years < 0 {
+		return 0
+	}
+	return years
+}
+
+// Entitlement tính số ngày phép được hưởng của năm year tính đến ngày asOf.
+// Nhân viên vào làm trong năm được hưởng theo tỷ lệ số tháng làm việc.
+func (p LeavePolicy) Entitlement(year int, hireDate, asOf time.Time) float64 {
+	if p.AnnualDays <= 0 {
+		return 0
+	}
+
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, asOf.Location())
+	yearEnd := time.Date(year, time.December, 31, 0, 0, 0, 0, asOf.Location())
+	if !hireDate.IsZero() && hireDate.After(yearEnd) {
+		return 0
+	}
+
+	// Thâm niên được xét tại cuối năm (hoặc ngày asOf nếu sớm hơn)
+	reference := yearEnd
+	if asOf.Before(reference) {
+		reference = asOf
+	}
+	annual := p.AnnualDays
+	if p.SeniorityEveryYears > 0 && !hireDate.IsZero() {
+		annual += p.SeniorityBonusDays * float64(ServiceYears(hireDate, reference)/p.SeniorityEveryYears)
+	}
+
+	// Số tháng được tính: từ tháng vào làm (hoặc tháng 1) đến tháng 12
+	firstMonth := 1
+	if !hireDate.IsZero() && hireDate.After(yearStart) {
+		firstMonth = int(hireDate.Month())
+	}
+	lastMonth := 12
+	if p.MonthlyAccrual {
+		if asOf.Before(yearStart) {
+			return 0
+		}
+		if asOf.Year() == year {
+			lastMonth = int(asOf.Month())
+		}
+	}
+	months := lastMonth - firstMonth + 1
+	if months <= 0 {
+		return 0
+	}
+
+	return roundHalf(annual * float64(months) / 12)
+}
+
+// CarryOver tính số ngày được chuyển sang năm sau từ số dư còn lại
+func (p LeavePolicy) CarryOver(remaining float64) float64 {
+	if remaining <= 0 {
+		return 0
+	}
+	return math.Min(remaining, p.MaxCarryOverDays)
+}
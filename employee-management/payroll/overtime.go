@@ -0,0 +1,137 @@
+package payroll
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Loại giờ làm thêm theo ngày thực hiện
+const (
+	OvertimeWeekday = "weekday"
+	OvertimeWeekend = "weekend"
+	OvertimeHoliday = "holiday"
+)
+
+// OvertimePolicy là chính sách trả lương làm thêm giờ và giới hạn số giờ làm thêm
+type OvertimePolicy struct {
+	Multipliers     map[string]float64 // Hệ số theo loại ngày, ví dụ 1.5 cho ngày thường
+	NightPremium    float64            // Phụ cấp thêm cho giờ làm đêm, ví dụ 0.3
+	NightStart      time.Duration      // Giờ bắt đầu ca đêm, tính từ nửa đêm
+	NightEnd        time.Duration      // Giờ kết thúc ca đêm, tính từ nửa đêm
+	MonthlyCapHours float64
+	YearlyCapHours  float64
+}
+
+// DefaultOvertimePolicy là mức tối thiểu theo Bộ luật Lao động
+func DefaultOvertimePolicy() OvertimePolicy {
+	return OvertimePolicy{
+		Multipliers: map[string]float64{
+			OvertimeWeekday: 1.5,
+			OvertimeWeekend: 2.0,
+			OvertimeHoliday: 3.0,
+		},
+		NightPremium:    0.3,
+		NightStart:      22 * time.Hour,
+		NightEnd:        6 * time.Hour,
+		MonthlyCapHours: 40,
+		YearlyCapHours:  200,
+	}
+}
+
+// SetNightWindow đặt khung giờ làm đêm từ cấu hình dạng "HH:MM"
+func (p *OvertimePolicy) SetNightWindow(start, end string) error {
+	from, err := parseClock(start)
+	if err != nil {
+		return err
+	}
+	to, err := parseClock(end)
+	if err != nil {
+		return err
+	}
+	p.NightStart, p.NightEnd = from, to
+	return nil
+}
+
+// Multiplier trả về hệ số của loại ngày, cộng thêm phụ cấp nếu làm đêm
+func (p OvertimePolicy) Multiplier(category string, night bool) float64 {
+	multiplier := p.Multipliers[category]
+	if night {
+		multiplier += p.NightPremium
+	}
+	return multiplier
+}
+
+// Pay tính tiền làm thêm giờ từ đơn giá giờ công, làm tròn đến đồng
+func (p OvertimePolicy) Pay(hourlyRate float64, category string, night bool, hours float64) int {
+	return int(math.Round(hourlyRate * hours * p.Multiplier(category, night)))
+}
+
+// SplitHours chia khoảng làm thêm [start, end] ("HH:MM") thành số giờ ngày và số giờ đêm.
+// Khoảng có giờ kết thúc nhỏ hơn giờ bắt đầu được coi là kéo dài qua nửa đêm.
+func (p OvertimePolicy) SplitHours(start, end string) (day, night float64, err error) {
+	from, err := parseClock(start)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := parseClock(end)
+	if err != nil {
+		return 0, 0, err
+	}
+	if from == to {
+		return 0, 0, fmt.Errorf("overtime start and end must differ")
+	}
+	if to < from {
+		to += 24 * time.Hour
+	}
+
+	// Khung giờ đêm lặp lại mỗi ngày, xét hai ngày liên tiếp để phủ ca qua đêm
+	var nightDuration time.Duration
+	for offset := -24 * time.Hour; offset <= 24*time.Hour; offset += 24 * time.Hour {
+		nightFrom, nightTo := p.NightStart+offset, p.NightEnd+offset
+		if nightTo <= nightFrom {
+			nightTo += 24 * time.Hour
+		}
+		nightDuration += overlap(from, to, nightFrom, nightTo)
+	}
+
+	total := to - from
+	return (total - nightDuration).Hours(), nightDuration.Hours(), nil
+}
+
+// ClockAfter trả về giờ ("HH:MM") sau start một khoảng hours, quay vòng qua nửa đêm
+func ClockAfter(start string, hours float64) (string, error) {
+	from, err := parseClock(start)
+	if err != nil {
+		return "", err
+	}
+	to := (from + time.Duration(math.Round(hours*60))*time.Minute) % (24 * time.Hour)
+	return fmt.Sprintf("%02d:%02d", int(to/time.Hour), int(to%time.Hour/time.Minute)), nil
+}
+
+// overlap tính độ dài phần giao của hai khoảng thời gian
+func overlap(aFrom, aTo, bFrom, bTo time.Duration) time.Duration {
+	from, to := aFrom, aTo
+	if bFrom > from {
+		from = bFrom
+	}
+	if bTo < to {
+		to = bTo
+	}
+	if to <= from {
+		return 0
+	}
+	return to - from
+}
+
+// CapWarnings trả về cảnh báo khi tổng giờ làm thêm vượt giới hạn tháng hoặc năm
+func (p OvertimePolicy) CapWarnings(monthHours, yearHours float64) []string {
+	warnings := []string{}
+	if p.MonthlyCapHours > 0 && monthHours > p.MonthlyCapHours {
+		warnings = append(warnings, fmt.Sprintf("Monthly overtime %.1fh exceeds the cap of %.1fh", monthHours, p.MonthlyCapHours))
+	}
+	if p.YearlyCapHours > 0 && yearHours > p.YearlyCapHours {
+		warnings = append(warnings, fmt.Sprintf("Yearly overtime %.1fh exceeds the cap of %.1fh", yearHours, p.YearlyCapHours))
+	}
+	return warnings
+}
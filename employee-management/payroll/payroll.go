@@ -0,0 +1,72 @@
+// Package payroll chứa các phép tính lương không phụ thuộc vào cơ sở dữ liệu.
+package payroll
+
+import (
+	"sort"
+	"time"
+)
+
+// Rate là mức lương cơ bản theo tháng có hiệu lực từ một ngày
+type Rate struct {
+	Amount        int
+	EffectiveFrom time.Time
+}
+
+// truncateDay bỏ phần giờ để so sánh theo ngày
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// DaysBetween đếm số ngày trong khoảng [from, to], tính cả hai đầu
+func DaysBetween(from, to time.Time) int {
+	from, to = truncateDay(from), truncateDay(to)
+	if to.Before(from) {
+		return 0
+	}
+	return int(to.Sub(from).Hours()/24+0.5) + 1
+}
+
+// ProratedBaseSalary tính lương cơ bản của kỳ [start, end] theo các mức lương có hiệu lực.
+// Khi mức lương thay đổi giữa kỳ, mỗi mức được tính theo tỷ lệ số ngày lịch mà nó áp dụng.
+// Những ngày trước mức lương đầu tiên (ví dụ nhân viên vào làm giữa tháng) không được tính.
+// ok là false nếu không có mức lương nào có hiệu lực trong kỳ.
+func ProratedBaseSalary(rates []Rate, start, end time.Time) (amount int, ok bool) {
+	start, end = truncateDay(start), truncateDay(end)
+	totalDays := DaysBetween(start, end)
+	if totalDays == 0 || len(rates) == 0 {
+		return 0, false
+	}
+
+	sorted := make([]Rate, len(rates))
+	copy(sorted, rates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].EffectiveFrom.Before(sorted[j].EffectiveFrom)
+	})
+
+	var weighted int64
+	for i, rate := range sorted {
+		from := truncateDay(rate.EffectiveFrom)
+		if from.After(end) {
+			break
+		}
+		if from.Before(start) {
+			from = start
+		}
+
+		// Mức lương áp dụng đến ngày trước khi mức kế tiếp có hiệu lực
+		to := end
+		if i+1 < len(sorted) {
+			next := truncateDay(sorted[i+1].EffectiveFrom).AddDate(0, 0, -1)
+			if next.Before(to) {
+				to = next
+			}
+		}
+
+		if days := DaysBetween(from, to); days > 0 {
+			weighted += int64(rate.Amount) * int64(days)
+			ok = true
+		}
+	}
+
+	return int(weighted / int64(totalDays)), ok
+}
@@ -0,0 +1,84 @@
+package payroll
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule là lịch làm việc chuẩn dùng để phát hiện đi muộn, về sớm
+type Schedule struct {
+	Start        time.Duration // Giờ bắt đầu, tính từ nửa đêm
+	End          time.Duration // Giờ kết thúc, tính từ nửa đêm
+	GraceMinutes int           // Số phút cho phép đi muộn/về sớm không bị tính
+	Weekdays     map[time.Weekday]bool
+}
+
+// parseClock đọc giờ dạng "HH:MM"
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ParseSchedule tạo lịch làm việc từ cấu hình, weekdays là danh sách thứ trong tuần
+// dạng "1,2,3,4,5" (0 là Chủ nhật)
+func ParseSchedule(start, end string, graceMinutes int, weekdays string) (Schedule, error) {
+	schedule := Schedule{GraceMinutes: graceMinutes, Weekdays: map[time.Weekday]bool{}}
+
+	var err error
+	if schedule.Start, err = parseClock(start); err != nil {
+		return schedule, err
+	}
+	if schedule.End, err = parseClock(end); err != nil {
+		return schedule, err
+	}
+	if schedule.End <= schedule.Start {
+		return schedule, fmt.Errorf("schedule end %s must be after start %s", end, start)
+	}
+
+	for _, part := range strings.Split(weekdays, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || day < 0 || day > 6 {
+			return schedule, fmt.Errorf("invalid weekday %q", part)
+		}
+		schedule.Weekdays[time.Weekday(day)] = true
+	}
+	return schedule, nil
+}
+
+// IsWorkday cho biết ngày có phải ngày làm việc theo lịch hay không
+func (s Schedule) IsWorkday(day time.Time) bool {
+	return s.Weekdays[day.Weekday()]
+}
+
+// StartOn trả về thời điểm bắt đầu ca làm việc trong ngày
+func (s Schedule) StartOn(day time.Time) time.Time {
+	return truncateDay(day).Add(s.Start)
+}
+
+// EndOn trả về thời điểm kết thúc ca làm việc trong ngày
+func (s Schedule) EndOn(day time.Time) time.Time {
+	return truncateDay(day).Add(s.End)
+}
+
+// LateMinutes tính số phút đi muộn so với giờ bắt đầu, đã trừ thời gian cho phép
+func (s Schedule) LateMinutes(checkIn time.Time) int {
+	late := int(checkIn.Sub(s.StartOn(checkIn)).Minutes())
+	if late <= s.GraceMinutes {
+		return 0
+	}
+	return late
+}
+
+// EarlyLeaveMinutes tính số phút về sớm so với giờ kết thúc, đã trừ thời gian cho phép
+func (s Schedule) EarlyLeaveMinutes(checkOut time.Time) int {
+	early := int(s.EndOn(checkOut).Sub(checkOut).Minutes())
+	if early <= s.GraceMinutes {
+		return 0
+	}
+	return early
+}
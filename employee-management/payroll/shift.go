@@ -0,0 +1,31 @@
+package payroll
+
+import (
+	"fmt"
+	"time"
+)
+
+// ShiftDuration tính thời gian làm việc thực tế của ca, đã trừ thời gian nghỉ.
+// Ca có giờ kết thúc nhỏ hơn giờ bắt đầu được coi là ca qua đêm.
+func ShiftDuration(start, end string, breakMinutes int) (time.Duration, error) {
+	from, err := parseClock(start)
+	if err != nil {
+		return 0, err
+	}
+	to, err := parseClock(end)
+	if err != nil {
+		return 0, err
+	}
+	if from == to {
+		return 0, fmt.Errorf("shift start and end must differ")
+	}
+	if to < from {
+		to += 24 * time.Hour
+	}
+
+	duration := to - from - time.Duration(breakMinutes)*time.Minute
+	if breakMinutes < 0 || duration <= 0 {
+		return 0, fmt.Errorf("break of %d minutes does not fit in shift %s-%s", breakMinutes, start, end)
+	}
+	return duration, nil
+}
@@ -21,17 +21,50 @@ func SetupRouter() *gin.Engine {
 			employeeRoutes.POST("/", controllers.CreateEmployee)
 			employeeRoutes.PUT("/:id", controllers.UpdateEmployee)
 			employeeRoutes.DELETE("/:id", controllers.DeleteEmployee)
+			employeeRoutes.GET("/:id/compensation", controllers.GetEmployeeCompensation)
+			employeeRoutes.POST("/:id/compensation", controllers.CreateCompensationRevision)
+			employeeRoutes.POST("/:id/compensation/:revision_id/approve", controllers.ApproveCompensationRevision)
+			employeeRoutes.POST("/:id/compensation/:revision_id/reject", controllers.RejectCompensationRevision)
+			employeeRoutes.GET("/:id/timesheet", controllers.GetEmployeeTimesheet)
+			employeeRoutes.GET("/:id/leave-balances", controllers.GetEmployeeLeaveBalances)
+			employeeRoutes.GET("/:id/overtime", controllers.GetEmployeeOvertimeSummary)
+			employeeRoutes.GET("/:id/advances", controllers.GetEmployeeAdvanceBalance)
+			employeeRoutes.GET("/:id/availability", controllers.GetEmployeeAvailability)
+			employeeRoutes.GET("/:id/workassignments", controllers.GetEmployeeWorkAssignments)
+			employeeRoutes.POST("/:id/calendar-feed", controllers.CreateEmployeeCalendarFeed)
+			employeeRoutes.PUT("/:id/manager", controllers.SetEmployeeManager)
+			employeeRoutes.GET("/:id/management-chain", controllers.GetManagementChain)
+			employeeRoutes.GET("/:id/reports", controllers.GetEmployeeReports)
+			employeeRoutes.GET("/:id/memberships", controllers.GetEmployeeMemberships)
+			employeeRoutes.POST("/:id/transfer", controllers.TransferEmployee)
+			employeeRoutes.POST("/:id/promote", controllers.PromoteEmployee)
+			employeeRoutes.GET("/:id/contracts", controllers.GetEmployeeContracts)
+			employeeRoutes.POST("/:id/contracts", controllers.CreateEmployeeContract)
+			employeeRoutes.GET("/:id/documents", controllers.GetEmployeeDocuments)
+			employeeRoutes.POST("/:id/documents", controllers.UploadEmployeeDocument)
+			employeeRoutes.GET("/:id/documents/:document_id", controllers.DownloadEmployeeDocument)
+			employeeRoutes.DELETE("/:id/documents/:document_id", controllers.DeleteEmployeeDocument)
 		}
 
 		// Routes cho Department
 		departmentRoutes := apiV1.Group("/departments")
 		{
 			departmentRoutes.GET("/", controllers.GetDepartments)
+			departmentRoutes.GET("/tree", controllers.GetDepartmentTree)
 			departmentRoutes.GET("/:department_id/employees", controllers.GetEmployeesByDepartment)
+			departmentRoutes.GET("/:department_id/roster", controllers.GetDepartmentRoster)
 			departmentRoutes.POST("/", controllers.CreateDepartment)
 			departmentRoutes.PUT("/:id", controllers.UpdateDepartment)
 			departmentRoutes.DELETE("/:id", controllers.DeleteDepartment)
+			departmentRoutes.PUT("/:id/roster", controllers.SaveDepartmentRoster)
+			departmentRoutes.PUT("/:id/move", controllers.MoveDepartment)
+			departmentRoutes.PUT("/:id/head", controllers.SetDepartmentHead)
+			departmentRoutes.GET("/:department_id/headcount", controllers.GetDepartmentHeadcount)
+			departmentRoutes.PUT("/:id/headcount", controllers.SetHeadcountPlan)
+			departmentRoutes.POST("/:id/calendar-feed", controllers.CreateDepartmentCalendarFeed)
 		}
+		// Sơ đồ tổ chức theo cây phòng ban
+		apiV1.GET("/orgchart", controllers.GetOrgChart)
 
 		// Routes cho Position
 		positionRoutes := apiV1.Group("/positions")
@@ -43,6 +76,21 @@ func SetupRouter() *gin.Engine {
 			positionRoutes.PUT("/:id", controllers.UpdatePosition)
 			positionRoutes.DELETE("/:id", controllers.DeletePosition)
 		}
+		// Danh mục nhóm nghề và bậc lương của chức vụ
+		jobFamilies := apiV1.Group("/job-families")
+		{
+			jobFamilies.GET("/", controllers.GetJobFamilies)
+			jobFamilies.POST("/", controllers.CreateJobFamily)
+			jobFamilies.PUT("/:id", controllers.UpdateJobFamily)
+			jobFamilies.DELETE("/:id", controllers.DeleteJobFamily)
+		}
+		payGrades := apiV1.Group("/pay-grades")
+		{
+			payGrades.GET("/", controllers.GetPayGrades)
+			payGrades.POST("/", controllers.CreatePayGrade)
+			payGrades.PUT("/:id", controllers.UpdatePayGrade)
+			payGrades.DELETE("/:id", controllers.DeletePayGrade)
+		}
 		salaries := apiV1.Group("/salaries")
 		{
 			salaries.GET("/", controllers.GetSalaries)      // Lấy tất cả bảng lương
@@ -52,14 +100,138 @@ func SetupRouter() *gin.Engine {
 			salaries.DELETE("/:id", controllers.DeleteSalary)
 			salaries.PUT("/:id/pay", controllers.PaySalary) // Xóa bảng lương theo ID
 			salaries.GET("/stats", controllers.GetSalaryStatistics)
+			salaries.GET("/report", controllers.GetSalaryReport)                         // Thống kê theo phòng ban, chức vụ, kỳ
+			salaries.GET("/compa-ratio", controllers.GetCompaRatioReport)                // So sánh lương với khung lương theo phòng ban
+			salaries.POST("/bank-transfer", controllers.GenerateBankTransferFile)        // Tạo file chuyển khoản ngân hàng và ghi mã tham chiếu
+			salaries.POST("/bank-transfer/reconcile", controllers.ReconcileBankTransfer) // Đối soát kết quả từ ngân hàng
+			salaries.POST("/generate", controllers.GenerateSalaries)                     // Lập bảng lương nháp cho một kỳ
+			salaries.GET("/:id/approvals", controllers.GetSalaryApprovals)               // Lịch sử phê duyệt
+			salaries.POST("/:id/prepare", controllers.PrepareSalary)
+			salaries.POST("/:id/review", controllers.ReviewSalary)
+			salaries.POST("/:id/approve", controllers.ApproveSalary)
+			salaries.POST("/:id/reject", controllers.RejectSalary)
 
 		}
+		// Routes cho định biên và tuyển dụng
+		vacancies := apiV1.Group("/vacancies")
+		{
+			vacancies.GET("/", controllers.GetVacancies)
+			vacancies.POST("/", controllers.OpenVacancy)
+			vacancies.POST("/:id/fill", controllers.FillVacancy)
+			vacancies.POST("/:id/cancel", controllers.CancelVacancy)
+		}
+		// Routes cho hợp đồng lao động
+		contracts := apiV1.Group("/contracts")
+		{
+			contracts.GET("/expiring", controllers.GetExpiringContracts)
+			contracts.GET("/:id/document", controllers.GetContractDocument)
+			contracts.POST("/:id/renew", controllers.RenewContract)
+			contracts.POST("/:id/terminate", controllers.TerminateContract)
+		}
+		// Routes cho chấm công
+		attendance := apiV1.Group("/attendance")
+		{
+			attendance.GET("/", controllers.GetAttendance)
+			attendance.POST("/check-in", controllers.CheckIn)
+			attendance.POST("/check-out", controllers.CheckOut)
+			attendance.POST("/corrections", controllers.CorrectAttendance)
+		}
+		// Routes cho nghỉ phép
+		leaveTypes := apiV1.Group("/leave-types")
+		{
+			leaveTypes.GET("/", controllers.GetLeaveTypes)
+			leaveTypes.POST("/", controllers.CreateLeaveType)
+			leaveTypes.PUT("/:id", controllers.UpdateLeaveType)
+		}
+		leaves := apiV1.Group("/leaves")
+		{
+			leaves.GET("/", controllers.GetLeaveRequests)
+			leaves.POST("/", controllers.CreateLeaveRequest)
+			leaves.POST("/:id/approve", controllers.ApproveLeaveRequest)
+			leaves.POST("/:id/reject", controllers.RejectLeaveRequest)
+			leaves.POST("/:id/cancel", controllers.CancelLeaveRequest)
+		}
+		// Routes cho lịch nghỉ lễ
+		holidays := apiV1.Group("/holidays")
+		{
+			holidays.GET("/", controllers.GetHolidays)
+			holidays.POST("/", controllers.CreateHoliday)
+			holidays.POST("/generate", controllers.GenerateHolidays)
+			holidays.DELETE("/:id", controllers.DeleteHoliday)
+		}
+		calendarRoutes := apiV1.Group("/calendar")
+		{
+			calendarRoutes.GET("/business-days", controllers.GetBusinessDays)
+			calendarRoutes.GET("/lunar", controllers.ConvertLunarDate)
+			// Lịch iCalendar, đường dẫn GET được xác thực bằng mã bí mật thay cho header
+			calendarRoutes.GET("/feeds", controllers.GetCalendarFeeds)
+			calendarRoutes.GET("/feeds/:token", controllers.GetCalendarFeedICS)
+			calendarRoutes.DELETE("/feeds/:token", controllers.DeleteCalendarFeed)
+		}
+		// Routes cho ca làm việc
+		shifts := apiV1.Group("/shifts")
+		{
+			shifts.GET("/", controllers.GetShiftTemplates)
+			shifts.POST("/", controllers.CreateShiftTemplate)
+			shifts.PUT("/:id", controllers.UpdateShiftTemplate)
+			shifts.DELETE("/:id", controllers.DeleteShiftTemplate)
+		}
+		shiftSwaps := apiV1.Group("/shift-swaps")
+		{
+			shiftSwaps.GET("/", controllers.GetShiftSwapRequests)
+			shiftSwaps.POST("/", controllers.CreateShiftSwapRequest)
+			shiftSwaps.POST("/:id/accept", controllers.AcceptShiftSwapRequest)
+			shiftSwaps.POST("/:id/approve", controllers.ApproveShiftSwapRequest)
+			shiftSwaps.POST("/:id/reject", controllers.RejectShiftSwapRequest)
+		}
+		// Routes cho làm thêm giờ
+		overtime := apiV1.Group("/overtime")
+		{
+			overtime.GET("/", controllers.GetOvertimeRequests)
+			overtime.POST("/", controllers.CreateOvertimeRequest)
+			overtime.POST("/:id/approve", controllers.ApproveOvertimeRequest)
+			overtime.POST("/:id/reject", controllers.RejectOvertimeRequest)
+			overtime.POST("/:id/record", controllers.RecordOvertime)
+		}
+		// Routes cho tạm ứng lương
+		advances := apiV1.Group("/advances")
+		{
+			advances.GET("/", controllers.GetSalaryAdvances)
+			advances.POST("/", controllers.CreateSalaryAdvance)
+			advances.POST("/:id/approve", controllers.ApproveSalaryAdvance)
+			advances.POST("/:id/reject", controllers.RejectSalaryAdvance)
+			advances.POST("/:id/disburse", controllers.DisburseSalaryAdvance)
+		}
+		// Routes cho chi phí công tác
+		expenses := apiV1.Group("/expenses")
+		{
+			expenses.GET("/", controllers.GetExpenseClaims)
+			expenses.GET("/:id/receipt", controllers.GetExpenseReceipt)
+			expenses.POST("/:id/approve", controllers.ApproveExpenseClaim)
+			expenses.POST("/:id/reject", controllers.RejectExpenseClaim)
+		}
+		perDiemRules := apiV1.Group("/per-diem-rules")
+		{
+			perDiemRules.GET("/", controllers.GetPerDiemRules)
+			perDiemRules.POST("/", controllers.CreatePerDiemRule)
+			perDiemRules.PUT("/:id", controllers.UpdatePerDiemRule)
+			perDiemRules.DELETE("/:id", controllers.DeletePerDiemRule)
+		}
 		workassignments := apiV1.Group("/workassignments")
 		{
 			workassignments.GET("/", controllers.GetWorkAssignments)
 			workassignments.POST("/", controllers.CreateWorkAssignment)
+			workassignments.POST("/import", controllers.ImportWorkAssignments)
 			workassignments.PUT("/:id", controllers.UpdateWorkAssignment)
 			workassignments.DELETE("/:id", controllers.DeleteWorkAssignment)
+			workassignments.GET("/:id/history", controllers.GetWorkAssignmentHistory)
+			workassignments.POST("/:id/start", controllers.StartWorkAssignment)
+			workassignments.POST("/:id/complete", controllers.CompleteWorkAssignment)
+			workassignments.POST("/:id/cancel", controllers.CancelWorkAssignment)
+			workassignments.PUT("/:id/progress", controllers.UpdateWorkAssignmentProgress)
+			workassignments.GET("/:id/expenses", controllers.GetWorkAssignmentExpenses)
+			workassignments.POST("/:id/expenses", controllers.CreateExpenseClaim)
+			workassignments.POST("/:id/per-diem", controllers.ClaimPerDiem)
 		}
 	}
 
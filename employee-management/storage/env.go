@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// EnvConfig đọc cấu hình backend từ biến môi trường DOCUMENT_STORAGE, DOCUMENT_DIR và S3_*
+func EnvConfig() Config {
+	dir := os.Getenv("DOCUMENT_DIR")
+	if dir == "" {
+		dir = filepath.Join("uploads", "documents")
+	}
+	return Config{
+		Backend:   os.Getenv("DOCUMENT_STORAGE"),
+		Dir:       dir,
+		Endpoint:  os.Getenv("S3_ENDPOINT"),
+		Region:    os.Getenv("S3_REGION"),
+		Bucket:    os.Getenv("S3_BUCKET"),
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+		PathStyle: os.Getenv("S3_PATH_STYLE") == "true",
+	}
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalRejectsEscapingKeys(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocal(filepath.Join(dir, "root"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, key := range []string{"", "/", "..", "../secret.pdf", "employees/../../secret.pdf", "employees/1/..", `employees\..\..\secret.pdf`} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, "application/pdf"); err == nil {
+			t.Errorf("Put(%q) succeeded, want error", key)
+		}
+		if _, err := store.Open(ctx, key); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Open(%q) error = %v, want invalid key", key, err)
+		}
+		if err := store.Delete(ctx, key); err == nil {
+			t.Errorf("Delete(%q) succeeded, want error", key)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "secret.pdf")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file written outside the storage root: %v", err)
+	}
+}
+
+func TestLocalPutOpenDelete(t *testing.T) {
+	store, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	const key = "employees/12/contract.pdf"
+
+	if err := store.Put(ctx, key, strings.NewReader("%PDF-1.4"), 8, "application/pdf"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	reader, err := store.Open(ctx, key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	content, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil || string(content) != "%PDF-1.4" {
+		t.Fatalf("Open content = %q, %v", content, err)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Open(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Open after delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete of a missing file: %v", err)
+	}
+}
+
+func TestLocalPutRejectsShortBody(t *testing.T) {
+	store, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := store.Put(ctx, "employees/1/a.pdf", strings.NewReader("abc"), 10, ""); err == nil {
+		t.Fatal("Put with a short body succeeded, want error")
+	}
+	if _, err := store.Open(ctx, "employees/1/a.pdf"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Open after failed Put error = %v, want ErrNotFound", err)
+	}
+}
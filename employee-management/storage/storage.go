@@ -0,0 +1,53 @@
+// Package storage lưu và đọc tệp đính kèm trên các backend khác nhau (ổ đĩa cục bộ, dịch vụ tương thích S3).
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Tên các backend lưu trữ
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ErrNotFound báo tệp không tồn tại trong backend
+var ErrNotFound = errors.New("object not found")
+
+// Storage lưu tệp theo khóa, khóa dùng dấu / để phân cấp, ví dụ "employees/12/abc.pdf"
+type Storage interface {
+	// Put ghi size byte từ body vào khóa key, ghi đè nếu đã tồn tại
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Open mở tệp để đọc, trả về ErrNotFound nếu không có
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete xóa tệp, không báo lỗi nếu tệp không tồn tại
+	Delete(ctx context.Context, key string) error
+}
+
+// Config là cấu hình chọn và khởi tạo backend
+type Config struct {
+	Backend string // local hoặc s3, mặc định là local
+	Dir     string // Thư mục gốc của backend local
+
+	Endpoint  string // Địa chỉ dịch vụ S3, ví dụ https://s3.ap-southeast-1.amazonaws.com hoặc http://localhost:9000
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool // Dùng endpoint/bucket/key thay vì bucket.endpoint/key, cần cho MinIO
+}
+
+// New khởi tạo backend theo cấu hình
+func New(cfg Config) (Storage, error) {
+	switch cfg.Backend {
+	case "", BackendLocal:
+		return NewLocal(cfg.Dir)
+	case BackendS3:
+		return NewS3(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}